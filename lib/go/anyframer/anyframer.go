@@ -9,16 +9,25 @@ import (
 
 // AnyFramer defines the framer options
 type AnyFramer struct {
-	Name         string      `json:"name,omitempty"`
-	InputType    InputType   `json:"inputType,omitempty"`
-	RawURL       string      `json:"rawUrl,omitempty"`
-	Headers      http.Header `json:"headers,omitempty"`
-	RootSelector string      `json:"rootSelector,omitempty"`
-	Columns      []Column    `json:"columns,omitempty"`
-	CSVOptions   CSVOptions  `json:"csvOptions,omitempty"`
+	Name           string         `json:"name,omitempty"`
+	RefID          string         `json:"refId,omitempty"`
+	InputType      InputType      `json:"inputType,omitempty"`
+	RawURL         string         `json:"rawUrl,omitempty"`
+	Headers        http.Header    `json:"headers,omitempty"`
+	RootSelector   string         `json:"rootSelector,omitempty"`
+	Columns        []Column       `json:"columns,omitempty"`
+	CSVOptions     CSVOptions     `json:"csvOptions,omitempty"`
+	NDJSONOptions  NDJSONOptions  `json:"ndjsonOptions,omitempty"`
+	HTMLOptions    HTMLOptions    `json:"htmlOptions,omitempty"`
+	XLSXOptions    XLSXOptions    `json:"xlsxOptions,omitempty"`
+	SplitOptions   SplitOptions   `json:"splitOptions,omitempty"`
+	FlattenOptions FlattenOptions `json:"flattenOptions,omitempty"`
+	Explode        ExplodeOptions `json:"explode,omitempty"`
+	Schema         []SchemaField  `json:"schema,omitempty"`
 }
 
-// ToFrame converts the given input string or input interface to data frame
+// ToFrame converts the given input string, input bytes or input interface to data frame.
+// Arrow and Parquet inputs are read directly from the bytes and retain the column types
 func (framerOptions *AnyFramer) ToFrame(input any) (*data.Frame, error) {
 	if framerOptions.Name == "" {
 		framerOptions.Name = "response"
@@ -28,11 +37,22 @@ func (framerOptions *AnyFramer) ToFrame(input any) (*data.Frame, error) {
 		if framerOptions.InputType == "" || framerOptions.InputType == InputTypeUnknown {
 			framerOptions.InputType = framerOptions.GuessType(input.(string))
 		}
+		if isBinaryInputType(framerOptions.InputType) {
+			return toFrameFromBytes([]byte(t), *framerOptions)
+		}
 		jsonObject, err := framerOptions.toJSONObject(input.(string))
 		if err != nil {
 			return nil, err
 		}
 		return toFrameFromInterface(jsonObject, *framerOptions)
+	case []byte:
+		if framerOptions.InputType == "" || framerOptions.InputType == InputTypeUnknown {
+			framerOptions.InputType = guessInputTypeFromBytes(t)
+		}
+		if isBinaryInputType(framerOptions.InputType) {
+			return toFrameFromBytes(t, *framerOptions)
+		}
+		return framerOptions.ToFrame(string(t))
 	default:
 		noop(t)
 		return toFrameFromInterface(input, *framerOptions)
@@ -44,23 +64,86 @@ func toFrameFromInterface(input any, options AnyFramer) (*data.Frame, error) {
 	if err != nil {
 		return nil, err
 	}
+	if len(options.Schema) > 0 && len(options.Columns) == 0 {
+		switch x := input.(type) {
+		case []any:
+		case map[string]any:
+			input = []any{x}
+		default:
+			input = []any{map[string]any{options.Name: x}}
+		}
+	}
 	switch x := input.(type) {
 	case nil, string, float64, float32, int64, int32, int16, int8, int, uint64, uint32, uint16, uint8, uint, bool, time.Time:
-		return StructToFrame(options.Name, map[string]any{options.Name: input})
+		return StructToFrame(options.Name, map[string]any{options.Name: input}, options.Columns...)
 	case []any:
-		return SliceToFrame(options.Name, input.([]any), options.Columns)
+		return options.toFrameFromSlice(options.Name, x)
 	default:
 		noop(x)
-		return StructToFrame(options.Name, input)
+		return StructToFrame(options.Name, input, options.Columns...)
 	}
 }
 
+// toFrameFromSlice explodes and flattens the items and converts them to frame using the columns or the schema
+func (options AnyFramer) toFrameFromSlice(name string, items []any) (*data.Frame, error) {
+	if len(options.Explode.Selectors) > 0 {
+		var err error
+		if items, err = explodeSlice(items, options.Explode); err != nil {
+			return nil, err
+		}
+	}
+	if options.FlattenOptions.Enabled && len(options.Columns) == 0 {
+		var err error
+		if items, err = flattenSlice(items, options.FlattenOptions); err != nil {
+			return nil, err
+		}
+	}
+	return options.sliceToFrame(name, items)
+}
+
 // CSVOptions ...
+// When InferTypes is set, number, boolean and timestamp columns are inferred by sampling the first InferTypesSampleSize rows.
+// Delimiter and Comment can be multiple characters. Encoding can be utf-8 (default), utf-16, utf-16le, utf-16be or latin1
 type CSVOptions struct {
-	Delimiter          string   `json:"delimiter,omitempty"`
-	Comment            string   `json:"comment,omitempty"`
-	RelaxColumnCount   bool     `json:"relaxColumnCount,omitempty"`
-	SkipLinesWithError bool     `json:"skipLinesWithError,omitempty"`
-	NoHeaders          bool     `json:"noHeaders,omitempty"`
-	Headers            []string `json:"headers,omitempty"`
+	Delimiter            string   `json:"delimiter,omitempty"`
+	Quote                string   `json:"quote,omitempty"`
+	Comment              string   `json:"comment,omitempty"`
+	Encoding             string   `json:"encoding,omitempty"`
+	SkipRows             int      `json:"skipRows,omitempty"`
+	RelaxColumnCount     bool     `json:"relaxColumnCount,omitempty"`
+	SkipLinesWithError   bool     `json:"skipLinesWithError,omitempty"`
+	NoHeaders            bool     `json:"noHeaders,omitempty"`
+	Headers              []string `json:"headers,omitempty"`
+	InferTypes           bool     `json:"inferTypes,omitempty"`
+	InferTypesSampleSize int      `json:"inferTypesSampleSize,omitempty"`
+}
+
+// NDJSONOptions ...
+type NDJSONOptions struct {
+	SkipMalformedLines bool `json:"skipMalformedLines,omitempty"`
+}
+
+// HTMLMode ...
+type HTMLMode string
+
+const (
+	// HTMLModeXML converts the html document as xml. This is the default mode
+	HTMLModeXML HTMLMode = "xml"
+	// HTMLModeTable extracts the html tables using a html5 parser
+	HTMLModeTable HTMLMode = "table"
+)
+
+// HTMLOptions ...
+type HTMLOptions struct {
+	Mode          HTMLMode `json:"mode,omitempty"`
+	TableIndex    int      `json:"tableIndex,omitempty"`
+	TableSelector string   `json:"tableSelector,omitempty"`
+}
+
+// XLSXOptions ...
+type XLSXOptions struct {
+	Sheet      string `json:"sheet,omitempty"`
+	SheetIndex int    `json:"sheetIndex,omitempty"`
+	Range      string `json:"range,omitempty"`
+	NoHeaders  bool   `json:"noHeaders,omitempty"`
 }
@@ -59,6 +59,31 @@ func TestToFrame(t *testing.T) {
 	t.Run("basic tsv", testToFrame(testInputs{
 		input: "a	b	c\n1	2	3\n4	5	6",
 	}))
+	t.Run("csv with inferred types", testToFrame(testInputs{
+		input:  "name,salary,self_employed,joined,year\nfoo,123,false,2023-01-02T03:04:05Z,2021\nbar,456.789,TRUE,2023-02-03,2022\nbaz,,,,",
+		framer: Framer{InputType: anyframer.InputTypeCSV, CSVOptions: anyframer.CSVOptions{InferTypes: true}},
+	}))
+	t.Run("csv with inferred types and unparsable cells", testToFrame(testInputs{
+		input:  "name,salary\nfoo,123\nbar,456\nbaz,unknown",
+		framer: Framer{InputType: anyframer.InputTypeCSV, CSVOptions: anyframer.CSVOptions{InferTypes: true, InferTypesSampleSize: 2}},
+	}))
+	t.Run("tsv with inferred types", testToFrame(testInputs{
+		input:  "a\tb\tc\n1\t2\tfoo\n4\t5\tbar",
+		framer: Framer{InputType: anyframer.InputTypeTSV, CSVOptions: anyframer.CSVOptions{InferTypes: true}},
+	}))
+	t.Run("csv with encoding, quote, multi character delimiter and skip rows", testToFrame(testInputs{
+		input:  "Exported report\nname||city||temp\n'foo||bar'||M\xfcnchen||12\n'baz'||'Z\xfcrich'||",
+		framer: Framer{InputType: anyframer.InputTypeCSV, CSVOptions: anyframer.CSVOptions{Encoding: "latin1", Quote: "'", Delimiter: "||", SkipRows: 1, InferTypes: true}},
+	}))
+	t.Run("csv with utf-16 encoding and headers", testToFrame(testInputs{
+		input:  "\xff\xfe1\x00,\x00f\x00o\x00o\x00\n\x002\x00,\x00b\x00a\x00r\x00\n\x00",
+		framer: Framer{InputType: anyframer.InputTypeCSV, CSVOptions: anyframer.CSVOptions{Encoding: "utf-16", Headers: []string{"id", "name"}}},
+	}))
+	t.Run("csv with only comments should throw error", testToFrame(testInputs{
+		input:   "# foo\n# bar",
+		framer:  Framer{InputType: anyframer.InputTypeCSV, CSVOptions: anyframer.CSVOptions{Comment: "#"}},
+		wantErr: errors.New("invalid/empty csv"),
+	}))
 	t.Run("simple string array", testToFrame(testInputs{
 		input: `["foo","bar"]`,
 	}))
@@ -74,6 +99,37 @@ func TestToFrame(t *testing.T) {
 	t.Run("nested json", testToFrame(testInputs{
 		input: `{ "users" : [{"name":"foo","salary": 123, "self_employed":false},{"name":"bar","salary": 456.789, "self_employed":true}] }`,
 	}))
+	t.Run("simple ndjson", testToFrame(testInputs{
+		input: "{\"name\":\"foo\",\"salary\": 123, \"self_employed\":false}\n{\"name\":\"bar\",\"salary\": 456.789, \"self_employed\":true}\n",
+	}))
+	t.Run("ndjson with malformed lines skipped", testToFrame(testInputs{
+		input:  "{\"name\":\"foo\"}\n{\"name\":\n{\"name\":\"bar\"}",
+		framer: Framer{InputType: anyframer.InputTypeNDJSON, NDJSONOptions: anyframer.NDJSONOptions{SkipMalformedLines: true}},
+	}))
+	t.Run("ndjson with columns and root selector", testToFrame(testInputs{
+		input:  "{\"user\":{\"name\":\"foo\",\"age\":\"12\"}}\n{\"user\":{\"name\":\"bar\",\"age\":\"34\"}}",
+		framer: Framer{InputType: anyframer.InputTypeNDJSON, RootSelector: "user", Columns: []anyframer.Column{{Selector: "name", Format: anyframer.ColumnFormatString}, {Selector: "age", Format: anyframer.ColumnFormatNumber}}},
+	}))
+	t.Run("simple yaml", testToFrame(testInputs{
+		input:  "- name: foo\n  salary: 123\n  self_employed: false\n- name: bar\n  salary: 456.789\n  self_employed: true\n",
+		framer: Framer{InputType: anyframer.InputTypeYAML},
+	}))
+	t.Run("yaml with non string keys and root selector", testToFrame(testInputs{
+		input:  "users:\n  - name: foo\n    1: one\n    true: yes\n  - name: bar\n    1: two\n    true: no\n",
+		framer: Framer{InputType: anyframer.InputTypeYAML, RootSelector: "users"},
+	}))
+	t.Run("multi document yaml", testToFrame(testInputs{
+		input:  "---\nname: foo\nsalary: 123\n---\nname: bar\nsalary: 456.789\n",
+		framer: Framer{InputType: anyframer.InputTypeYAML},
+	}))
+	t.Run("simple toml", testToFrame(testInputs{
+		input:  "[[users]]\nname = \"foo\"\nsalary = 123\njoined = 2023-01-02T03:04:05Z\n\n[[users]]\nname = \"bar\"\nsalary = 456.789\njoined = 2023-02-03T04:05:06Z\n",
+		framer: Framer{InputType: anyframer.InputTypeTOML, RootSelector: "users", Columns: []anyframer.Column{{Selector: "name", Format: anyframer.ColumnFormatString}, {Selector: "salary", Format: anyframer.ColumnFormatNumber}, {Selector: "joined", Format: anyframer.ColumnFormatTimeStamp}}},
+	}))
+	t.Run("simple toml without root selector", testToFrame(testInputs{
+		input:  "title = \"example\"\nversion = 2\n\n[owner]\nname = \"foo\"\n",
+		framer: Framer{InputType: anyframer.InputTypeTOML},
+	}))
 	t.Run("simple xml", testToFrame(testInputs{
 		input: `<?xml version="1.0" encoding="UTF-8"?><root><row><name>foo</name><salary>123</salary><self_employed>false</self_employed></row><row><name>bar</name><salary>456.789</salary><self_employed>true</self_employed></row></root>`,
 	}))
@@ -88,6 +144,86 @@ func TestToFrame(t *testing.T) {
 		input:  `<html><body><table class="table table-bordered table-hover table-condensed"><thead><tr><th title="Field #1">name</th><th title="Field #2">salary</th><th title="Field #3">self_employed</th></tr></thead><tbody><tr><td>foo</td><td align="right">123</td><td>false</td></tr><tr><td>bar</td><td align="right">456.789</td><td>true</td></tr></tbody></table></body></html>`,
 		framer: Framer{RootSelector: "html.body.table.tbody.tr"},
 	}))
+	t.Run("html table", testToFrame(testInputs{
+		input:  `<html><body><p>users</p><table><thead><tr><th>name</th><th>salary</th></tr></thead><tbody><tr><td>foo</td><td>123</td></tr><tr><td><b>bar</b>  baz</td><td>456.789</td></tr></tbody></table></body></html>`,
+		framer: Framer{HTMLOptions: anyframer.HTMLOptions{Mode: anyframer.HTMLModeTable}, Columns: []anyframer.Column{{Selector: "name", Alias: "user", Format: anyframer.ColumnFormatString}, {Selector: "salary", Format: anyframer.ColumnFormatNumber}}},
+	}))
+	t.Run("html table with colspan and rowspan", testToFrame(testInputs{
+		input:  `<table><tr><th rowspan="2">name</th><th colspan="2">address</th></tr><tr><th>city</th><th>country</th></tr><tr><td rowspan="2">foo</td><td>london</td><td>uk</td></tr><tr><td colspan="2">remote</td></tr></table>`,
+		framer: Framer{InputType: anyframer.InputTypeHTML, HTMLOptions: anyframer.HTMLOptions{Mode: anyframer.HTMLModeTable}},
+	}))
+	t.Run("html table without headers", testToFrame(testInputs{
+		input:  `<table><tr><td>foo</td><td>123</td></tr><tr><td>bar</td></tr></table>`,
+		framer: Framer{InputType: anyframer.InputTypeHTML, HTMLOptions: anyframer.HTMLOptions{Mode: anyframer.HTMLModeTable}},
+	}))
+	t.Run("html table by index", testToFrame(testInputs{
+		input:  `<table><tr><th>a</th></tr><tr><td>1</td></tr></table><table><tr><th>b</th><th>b</th></tr><tr><td>2</td><td>3</td></tr></table>`,
+		framer: Framer{InputType: anyframer.InputTypeHTML, HTMLOptions: anyframer.HTMLOptions{Mode: anyframer.HTMLModeTable, TableIndex: 1}},
+	}))
+	t.Run("html table by selector", testToFrame(testInputs{
+		input:  `<table><tr><th>a</th></tr><tr><td>1</td></tr></table><div class="users"><table><tr><th>name</th></tr><tr><td>foo</td></tr></table></div>`,
+		framer: Framer{InputType: anyframer.InputTypeHTML, HTMLOptions: anyframer.HTMLOptions{Mode: anyframer.HTMLModeTable, TableSelector: ".users table"}},
+	}))
+}
+
+func TestToFrame_HTMLTableSpans(t *testing.T) {
+	framer := Framer{InputType: anyframer.InputTypeHTML, HTMLOptions: anyframer.HTMLOptions{Mode: anyframer.HTMLModeTable}}
+	frame, err := framer.ToFrame(`<table><tr><th>a</th></tr><tr><td rowspan="100000000" colspan="100000000">x</td></tr><tr><td>y</td></tr></table>`)
+	require.Nil(t, err)
+	require.Equal(t, 2, frame.Rows())
+	require.Equal(t, 1001, len(frame.Fields))
+}
+
+func TestToFrame_HTMLTableDuplicateHeaders(t *testing.T) {
+	framer := Framer{InputType: anyframer.InputTypeHTML, HTMLOptions: anyframer.HTMLOptions{Mode: anyframer.HTMLModeTable}}
+	frame, err := framer.ToFrame(`<table><tr><th>a</th><th>a</th><th>a 2</th><th>a</th></tr><tr><td>1</td><td>2</td><td>3</td><td>4</td></tr></table>`)
+	require.Nil(t, err)
+	require.Equal(t, 4, len(frame.Fields))
+	for name, want := range map[string]any{"a": "1", "a 3": "2", "a 2": "3", "a 4": "4"} {
+		field, _ := frame.FieldByName(name)
+		require.NotNil(t, field, name)
+		value, _ := field.ConcreteAt(0)
+		require.Equal(t, want, value, name)
+	}
+}
+
+func TestToFrame_HTMLTables(t *testing.T) {
+	input := `<table id="users"><tr><th>name</th></tr><tr><td>foo</td></tr></table><table><caption> Pets </caption><tr><th>name</th></tr><tr><td>bar</td></tr></table><table><tr><th>id</th></tr></table>`
+	t.Run("should return a frame per table", func(t *testing.T) {
+		framer := Framer{HTMLOptions: anyframer.HTMLOptions{Mode: anyframer.HTMLModeTable}}
+		frames, err := framer.ToFramesFromHTMLTables(input)
+		require.Nil(t, err)
+		require.Equal(t, 3, len(frames))
+		require.Equal(t, "users", frames[0].Name)
+		require.Equal(t, "Pets", frames[1].Name)
+		require.Equal(t, "table 3", frames[2].Name)
+		require.Equal(t, 1, frames[1].Rows())
+	})
+	t.Run("invalid table index should throw error", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeHTML, HTMLOptions: anyframer.HTMLOptions{Mode: anyframer.HTMLModeTable, TableIndex: 5}}
+		_, err := framer.ToFrame(input)
+		require.EqualError(t, err, "invalid table index 5. 3 table(s) found")
+	})
+	t.Run("invalid selector should throw error", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeHTML, HTMLOptions: anyframer.HTMLOptions{Mode: anyframer.HTMLModeTable, TableSelector: "table["}}
+		_, err := framer.ToFrame(input)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), `invalid table selector "table["`)
+	})
+	t.Run("html without tables should throw error", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeHTML, HTMLOptions: anyframer.HTMLOptions{Mode: anyframer.HTMLModeTable}}
+		_, err := framer.ToFrame(`<html><body><p>foo</p></body></html>`)
+		require.EqualError(t, err, "no html tables found")
+	})
+}
+
+func TestToFrame_NDJSON(t *testing.T) {
+	t.Run("ndjson with malformed lines should throw error", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeNDJSON}
+		_, err := framer.ToFrame("{\"name\":\"foo\"}\n{\"name\":\n{\"name\":\"bar\"}")
+		require.NotNil(t, err)
+		require.EqualError(t, err, "error reading ndjson line 2. unexpected end of JSON input")
+	})
 }
 
 func TestAnyFile(t *testing.T) {
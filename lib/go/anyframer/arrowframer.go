@@ -0,0 +1,257 @@
+package anyframer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet/file"
+	"github.com/apache/arrow/go/v13/parquet/pqarrow"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+var (
+	arrowFileMagic   = []byte("ARROW1")
+	arrowStreamMagic = []byte{0xff, 0xff, 0xff, 0xff}
+	parquetMagic     = []byte("PAR1")
+)
+
+// recordReader is the common interface of the arrow ipc stream reader and parquet record reader
+type recordReader interface {
+	Next() bool
+	Record() arrow.Record
+	Err() error
+	Release()
+}
+
+func guessInputTypeFromBytes(input []byte) InputType {
+	if bytes.HasPrefix(input, parquetMagic) {
+		return InputTypeParquet
+	}
+	if bytes.HasPrefix(input, arrowFileMagic) || bytes.HasPrefix(input, arrowStreamMagic) {
+		return InputTypeArrow
+	}
+	return InputTypeUnknown
+}
+
+func isBinaryInputType(inputType InputType) bool {
+	return inputType == InputTypeArrow || inputType == InputTypeParquet
+}
+
+func toFrameFromBytes(input []byte, options AnyFramer) (*data.Frame, error) {
+	if len(input) == 0 {
+		return nil, errors.New("invalid/empty input")
+	}
+	switch options.InputType {
+	case InputTypeArrow:
+		return toFrameFromArrow(input, options)
+	case InputTypeParquet:
+		return toFrameFromParquet(input, options)
+	default:
+		return nil, fmt.Errorf("invalid binary input type %q", options.InputType)
+	}
+}
+
+func toFrameFromArrow(input []byte, options AnyFramer) (*data.Frame, error) {
+	if bytes.HasPrefix(input, arrowFileMagic) {
+		reader, err := ipc.NewFileReader(bytes.NewReader(input))
+		if err != nil {
+			return nil, fmt.Errorf("error reading arrow file. %w", err)
+		}
+		defer reader.Close()
+		frame, err := newArrowFrame(options.Name, reader.Schema())
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < reader.NumRecords(); i++ {
+			record, err := reader.Record(i)
+			if err != nil {
+				return nil, fmt.Errorf("error reading arrow record. %w", err)
+			}
+			if err := appendArrowRecord(frame, record); err != nil {
+				return nil, err
+			}
+		}
+		return applyArrowColumns(frame, options.Columns), nil
+	}
+	reader, err := ipc.NewReader(bytes.NewReader(input))
+	if err != nil {
+		return nil, fmt.Errorf("error reading arrow stream. %w", err)
+	}
+	defer reader.Release()
+	return toFrameFromRecordReader(reader, reader.Schema(), options)
+}
+
+func toFrameFromParquet(input []byte, options AnyFramer) (*data.Frame, error) {
+	pf, err := file.NewParquetReader(bytes.NewReader(input))
+	if err != nil {
+		return nil, fmt.Errorf("error reading parquet file. %w", err)
+	}
+	defer pf.Close()
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{BatchSize: 64 * 1024}, memory.DefaultAllocator)
+	if err != nil {
+		return nil, fmt.Errorf("error reading parquet file. %w", err)
+	}
+	reader, err := fr.GetRecordReader(context.Background(), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error reading parquet records. %w", err)
+	}
+	defer reader.Release()
+	return toFrameFromRecordReader(reader, reader.Schema(), options)
+}
+
+func toFrameFromRecordReader(reader recordReader, schema *arrow.Schema, options AnyFramer) (*data.Frame, error) {
+	frame, err := newArrowFrame(options.Name, schema)
+	if err != nil {
+		return nil, err
+	}
+	for reader.Next() {
+		if err := appendArrowRecord(frame, reader.Record()); err != nil {
+			return nil, err
+		}
+	}
+	if err := reader.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error reading arrow records. %w", err)
+	}
+	return applyArrowColumns(frame, options.Columns), nil
+}
+
+func newArrowFrame(name string, schema *arrow.Schema) (*data.Frame, error) {
+	frame := data.NewFrame(name)
+	for _, f := range schema.Fields() {
+		field, err := newArrowField(f)
+		if err != nil {
+			return nil, err
+		}
+		frame.Fields = append(frame.Fields, field)
+	}
+	return frame, nil
+}
+
+func newArrowField(f arrow.Field) (*data.Field, error) {
+	switch f.Type.ID() {
+	case arrow.INT8:
+		return data.NewField(f.Name, nil, []*int8{}), nil
+	case arrow.INT16:
+		return data.NewField(f.Name, nil, []*int16{}), nil
+	case arrow.INT32:
+		return data.NewField(f.Name, nil, []*int32{}), nil
+	case arrow.INT64:
+		return data.NewField(f.Name, nil, []*int64{}), nil
+	case arrow.UINT8:
+		return data.NewField(f.Name, nil, []*uint8{}), nil
+	case arrow.UINT16:
+		return data.NewField(f.Name, nil, []*uint16{}), nil
+	case arrow.UINT32:
+		return data.NewField(f.Name, nil, []*uint32{}), nil
+	case arrow.UINT64:
+		return data.NewField(f.Name, nil, []*uint64{}), nil
+	case arrow.FLOAT32:
+		return data.NewField(f.Name, nil, []*float32{}), nil
+	case arrow.FLOAT64:
+		return data.NewField(f.Name, nil, []*float64{}), nil
+	case arrow.BOOL:
+		return data.NewField(f.Name, nil, []*bool{}), nil
+	case arrow.STRING, arrow.LARGE_STRING:
+		return data.NewField(f.Name, nil, []*string{}), nil
+	case arrow.TIMESTAMP, arrow.DATE32, arrow.DATE64:
+		return data.NewField(f.Name, nil, []*time.Time{}), nil
+	default:
+		return nil, fmt.Errorf("unsupported arrow type %s for column %q", f.Type, f.Name)
+	}
+}
+
+// appendArrowRecord appends the values of the record to the frame. Timestamps are converted using the unit and zone of the column
+func appendArrowRecord(frame *data.Frame, record arrow.Record) error {
+	if int(record.NumCols()) != len(frame.Fields) {
+		return fmt.Errorf("invalid arrow record. expected %d columns, got %d", len(frame.Fields), record.NumCols())
+	}
+	for i, field := range frame.Fields {
+		col := record.Column(i)
+		var toTime func(arrow.Timestamp) time.Time
+		if tsType, ok := col.DataType().(*arrow.TimestampType); ok {
+			f, err := tsType.GetToTimeFunc()
+			if err != nil {
+				return fmt.Errorf("invalid timestamp zone for column %q. %w", field.Name, err)
+			}
+			toTime = f
+		}
+		for row := 0; row < col.Len(); row++ {
+			if col.IsNull(row) {
+				field.Append(nil)
+				continue
+			}
+			switch c := col.(type) {
+			case *array.Int8:
+				field.Append(toPointer(c.Value(row)))
+			case *array.Int16:
+				field.Append(toPointer(c.Value(row)))
+			case *array.Int32:
+				field.Append(toPointer(c.Value(row)))
+			case *array.Int64:
+				field.Append(toPointer(c.Value(row)))
+			case *array.Uint8:
+				field.Append(toPointer(c.Value(row)))
+			case *array.Uint16:
+				field.Append(toPointer(c.Value(row)))
+			case *array.Uint32:
+				field.Append(toPointer(c.Value(row)))
+			case *array.Uint64:
+				field.Append(toPointer(c.Value(row)))
+			case *array.Float32:
+				field.Append(toPointer(c.Value(row)))
+			case *array.Float64:
+				field.Append(toPointer(c.Value(row)))
+			case *array.Boolean:
+				field.Append(toPointer(c.Value(row)))
+			case *array.String:
+				field.Append(toPointer(c.Value(row)))
+			case *array.LargeString:
+				field.Append(toPointer(c.Value(row)))
+			case *array.Timestamp:
+				field.Append(toPointer(toTime(c.Value(row))))
+			case *array.Date32:
+				field.Append(toPointer(c.Value(row).ToTime()))
+			case *array.Date64:
+				field.Append(toPointer(c.Value(row).ToTime()))
+			default:
+				return fmt.Errorf("unsupported arrow type %s for column %q", col.DataType(), field.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// applyArrowColumns keeps only the selected columns and applies the column alias and field config. Column types are retained from the source
+func applyArrowColumns(frame *data.Frame, columns []Column) *data.Frame {
+	if len(columns) == 0 {
+		return frame
+	}
+	fields := []*data.Field{}
+	for _, col := range columns {
+		for _, field := range frame.Fields {
+			if field.Name != col.Selector {
+				continue
+			}
+			if col.Alias != "" {
+				field.Name = col.Alias
+			}
+			field.Config = col.fieldConfig()
+			fields = append(fields, field)
+			break
+		}
+	}
+	frame.Fields = fields
+	return frame
+}
+
+func toPointer[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,111 @@
+package anyframer_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet/pqarrow"
+	"github.com/grafana/grafana-plugin-sdk-go/experimental"
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+)
+
+func testArrowRecord(t *testing.T) arrow.Record {
+	t.Helper()
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "salary", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "self_employed", Type: arrow.FixedWidthTypes.Boolean},
+		{Name: "joined", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "Asia/Kolkata"}},
+		{Name: "updated", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}},
+	}, nil)
+	b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{9007199254740993, 2}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"foo", ""}, []bool{true, false})
+	b.Field(2).(*array.Float64Builder).AppendValues([]float64{123.456789012345, 0}, []bool{true, false})
+	b.Field(3).(*array.BooleanBuilder).AppendValues([]bool{false, true}, nil)
+	b.Field(4).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{1672531200000, 1672617600000}, nil)
+	b.Field(5).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{1672531200000001, 1672617600000002}, nil)
+	return b.NewRecord()
+}
+
+func TestToFrame_Arrow(t *testing.T) {
+	record := testArrowRecord(t)
+	defer record.Release()
+	t.Run("arrow stream", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := ipc.NewWriter(&buf, ipc.WithSchema(record.Schema()))
+		require.Nil(t, w.Write(record))
+		require.Nil(t, w.Write(record))
+		require.Nil(t, w.Close())
+		framer := Framer{}
+		frame, err := framer.ToFrame(buf.Bytes())
+		require.Nil(t, err)
+		require.Equal(t, anyframer.InputTypeArrow, framer.InputType)
+		require.Equal(t, 4, frame.Rows())
+		experimental.CheckGoldenJSONFrame(t, "testdata/golden", t.Name(), frame, updateGoldenFile)
+	})
+	t.Run("arrow file", func(t *testing.T) {
+		f, err := os.CreateTemp(t.TempDir(), "users.arrow")
+		require.Nil(t, err)
+		defer f.Close()
+		w, err := ipc.NewFileWriter(f, ipc.WithSchema(record.Schema()))
+		require.Nil(t, err)
+		require.Nil(t, w.Write(record))
+		require.Nil(t, w.Close())
+		b, err := os.ReadFile(f.Name())
+		require.Nil(t, err)
+		framer := Framer{RawURL: "https://foo.com/users.arrow", Columns: []anyframer.Column{{Selector: "joined", Alias: "Joined"}, {Selector: "id"}}}
+		frame, err := framer.ToFrame(b)
+		require.Nil(t, err)
+		require.Equal(t, 2, len(frame.Fields))
+		require.Equal(t, "Joined", frame.Fields[0].Name)
+		joined, ok := frame.Fields[0].ConcreteAt(0)
+		require.True(t, ok)
+		require.Equal(t, "2023-01-01T05:30:00+05:30", joined.(time.Time).Format(time.RFC3339))
+		id, _ := frame.Fields[1].ConcreteAt(0)
+		require.Equal(t, int64(9007199254740993), id)
+	})
+	t.Run("invalid arrow should throw error", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeArrow}
+		_, err := framer.ToFrame([]byte("foo"))
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "error reading arrow stream.")
+	})
+}
+
+func TestToFrame_Parquet(t *testing.T) {
+	record := testArrowRecord(t)
+	defer record.Release()
+	var buf bytes.Buffer
+	table := array.NewTableFromRecords(record.Schema(), []arrow.Record{record})
+	defer table.Release()
+	require.Nil(t, pqarrow.WriteTable(table, &buf, 1024, nil, pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema())))
+	t.Run("parquet", func(t *testing.T) {
+		framer := Framer{}
+		frame, err := framer.ToFrame(buf.Bytes())
+		require.Nil(t, err)
+		require.Equal(t, anyframer.InputTypeParquet, framer.InputType)
+		experimental.CheckGoldenJSONFrame(t, "testdata/golden", t.Name(), frame, updateGoldenFile)
+	})
+	t.Run("parquet as string input", func(t *testing.T) {
+		framer := Framer{RawURL: "users.parquet"}
+		frame, err := framer.ToFrame(buf.String())
+		require.Nil(t, err)
+		require.Equal(t, 2, frame.Rows())
+	})
+	t.Run("invalid parquet should throw error", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeParquet}
+		_, err := framer.ToFrame([]byte("PAR1foo"))
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "error reading parquet file.")
+	})
+}
@@ -1,11 +1,57 @@
 package anyframer
 
+import "github.com/grafana/grafana-plugin-sdk-go/data"
+
 // Column ...
+// TimeFormat accepts moment style (YYYY-MM-DD), strftime style (%Y-%m-%d) or go layouts. Values not matching the declared TimeFormat throw error.
+// Timezone such as Europe/London applies to the timestamp values without zone information. Defaults to UTC.
+// DisplayName, Unit, Decimals, Min, Max, Mappings and Links are applied as the field config. Links can refer other fields with ${__data.fields.x}
 type Column struct {
-	Selector   string       `json:"selector,omitempty"`
-	Alias      string       `json:"alias,omitempty"`
-	Format     ColumnFormat `json:"format,omitempty"`
-	TimeFormat string       `json:"timeFormat,omitempty"`
+	Selector    string             `json:"selector,omitempty"`
+	Alias       string             `json:"alias,omitempty"`
+	Format      ColumnFormat       `json:"format,omitempty"`
+	TimeFormat  string             `json:"timeFormat,omitempty"`
+	Timezone    string             `json:"timezone,omitempty"`
+	DisplayName string             `json:"displayName,omitempty"`
+	Unit        string             `json:"unit,omitempty"`
+	Decimals    *uint16            `json:"decimals,omitempty"`
+	Min         *float64           `json:"min,omitempty"`
+	Max         *float64           `json:"max,omitempty"`
+	Mappings    data.ValueMappings `json:"mappings,omitempty"`
+	Links       []data.DataLink    `json:"links,omitempty"`
+}
+
+// fieldConfig returns the field config declared on the column. nil when nothing is declared
+func (c Column) fieldConfig() *data.FieldConfig {
+	if c.DisplayName == "" && c.Unit == "" && c.Decimals == nil && c.Min == nil && c.Max == nil && len(c.Mappings) == 0 && len(c.Links) == 0 {
+		return nil
+	}
+	config := &data.FieldConfig{DisplayNameFromDS: c.DisplayName, Unit: c.Unit, Mappings: c.Mappings, Links: c.Links}
+	if c.Decimals != nil {
+		config.SetDecimals(*c.Decimals)
+	}
+	if c.Min != nil {
+		config.SetMin(*c.Min)
+	}
+	if c.Max != nil {
+		config.SetMax(*c.Max)
+	}
+	return config
+}
+
+// applyFieldConfig sets the field config of the columns to the fields matching the column selector
+func applyFieldConfig(frame *data.Frame, columns []Column) {
+	for _, column := range columns {
+		config := column.fieldConfig()
+		if config == nil {
+			continue
+		}
+		for _, field := range frame.Fields {
+			if field.Name == column.Selector {
+				field.Config = config
+			}
+		}
+	}
 }
 
 // ColumnFormat ...
@@ -24,4 +70,8 @@ const (
 	ColumnFormatUnixMsecTimeStamp ColumnFormat = "timestamp_epoch"
 	// ColumnFormatUnixSecTimeStamp ...
 	ColumnFormatUnixSecTimeStamp ColumnFormat = "timestamp_epoch_s"
+	// ColumnFormatUnixUsecTimeStamp ...
+	ColumnFormatUnixUsecTimeStamp ColumnFormat = "timestamp_epoch_us"
+	// ColumnFormatUnixNsecTimeStamp ...
+	ColumnFormatUnixNsecTimeStamp ColumnFormat = "timestamp_epoch_ns"
 )
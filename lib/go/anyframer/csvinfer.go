@@ -0,0 +1,106 @@
+package anyframer
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultCSVInferTypesSampleSize = 100
+
+type csvColumnType string
+
+const (
+	csvColumnTypeString    csvColumnType = "string"
+	csvColumnTypeNumber    csvColumnType = "number"
+	csvColumnTypeBoolean   csvColumnType = "boolean"
+	csvColumnTypeTimestamp csvColumnType = "timestamp"
+)
+
+// inferCSVTypes samples the rows to infer the type of each column and converts the values of all rows to the inferred type.
+// Cells that can't be parsed as the inferred type become nulls
+func inferCSVTypes(header []string, rows []any, options CSVOptions) []any {
+	sampleSize := options.InferTypesSampleSize
+	if sampleSize <= 0 {
+		sampleSize = defaultCSVInferTypesSampleSize
+	}
+	for _, h := range header {
+		columnType := inferCSVColumnType(h, rows, sampleSize)
+		if columnType == csvColumnTypeString {
+			continue
+		}
+		for _, row := range rows {
+			item, ok := row.(map[string]any)
+			if !ok {
+				continue
+			}
+			if v, ok := item[h].(string); ok {
+				item[h] = parseCSVValue(v, columnType)
+			}
+		}
+	}
+	return rows
+}
+
+func inferCSVColumnType(h string, rows []any, sampleSize int) csvColumnType {
+	candidates := map[csvColumnType]bool{csvColumnTypeNumber: true, csvColumnTypeBoolean: true, csvColumnTypeTimestamp: true}
+	sampled := 0
+	for _, row := range rows {
+		if sampled >= sampleSize {
+			break
+		}
+		item, ok := row.(map[string]any)
+		if !ok {
+			continue
+		}
+		v, ok := item[h].(string)
+		if !ok || strings.TrimSpace(v) == "" {
+			continue
+		}
+		sampled++
+		for columnType := range candidates {
+			if parseCSVValue(v, columnType) == nil {
+				delete(candidates, columnType)
+			}
+		}
+		if len(candidates) == 0 {
+			return csvColumnTypeString
+		}
+	}
+	if sampled == 0 {
+		return csvColumnTypeString
+	}
+	for _, columnType := range []csvColumnType{csvColumnTypeBoolean, csvColumnTypeNumber, csvColumnTypeTimestamp} {
+		if candidates[columnType] {
+			return columnType
+		}
+	}
+	return csvColumnTypeString
+}
+
+func parseCSVValue(v string, columnType csvColumnType) any {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil
+	}
+	switch columnType {
+	case csvColumnTypeNumber:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	case csvColumnTypeBoolean:
+		switch strings.ToLower(v) {
+		case "true":
+			return true
+		case "false":
+			return false
+		}
+	case csvColumnTypeTimestamp:
+		if t := getTimeFromString(v, time.UTC); t != nil {
+			return *t
+		}
+	default:
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package anyframer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExplodeMode ...
+type ExplodeMode string
+
+const (
+	// ExplodeModeOuter keeps the rows with empty / missing arrays with null value. This is the default mode
+	ExplodeModeOuter ExplodeMode = "outer"
+	// ExplodeModeInner drops the rows with empty / missing arrays
+	ExplodeModeInner ExplodeMode = "inner"
+)
+
+const defaultExplodeMaxRows = 100000
+
+// ExplodeOptions ...
+// Selectors are the dot separated property paths of the arrays to unnest, such as aliases or meta.regions.
+// Each array item creates a row with the parent fields repeated. Selectors are applied in order.
+// Exploding multiple arrays multiplies the rows, so explode fails when the rows exceed MaxRows (default 100000)
+type ExplodeOptions struct {
+	Selectors []string    `json:"selectors,omitempty"`
+	Mode      ExplodeMode `json:"mode,omitempty"`
+	MaxRows   int         `json:"maxRows,omitempty"`
+}
+
+func (options ExplodeOptions) maxRows() int {
+	if options.MaxRows <= 0 {
+		return defaultExplodeMaxRows
+	}
+	return options.MaxRows
+}
+
+func errExplodeMaxRows(maxRows int) error {
+	return fmt.Errorf("explode exceeded the maximum of %d rows", maxRows)
+}
+
+// explodeSlice unnests the arrays of each item in the input
+func explodeSlice(input []any, options ExplodeOptions) ([]any, error) {
+	maxRows := options.maxRows()
+	for _, selector := range options.Selectors {
+		path := strings.Split(strings.TrimSpace(selector), ".")
+		if len(path) == 0 || path[0] == "" {
+			continue
+		}
+		out := []any{}
+		for _, item := range input {
+			o, ok := item.(map[string]any)
+			if !ok {
+				out = append(out, item)
+				continue
+			}
+			value, found := getPathValue(o, path)
+			items, isArray := value.([]any)
+			if found && !isArray && value != nil {
+				out = append(out, item)
+				continue
+			}
+			if len(items) == 0 {
+				if options.Mode != ExplodeModeInner {
+					out = append(out, setPathValue(o, path, nil))
+				}
+				continue
+			}
+			if len(out)+len(items) > maxRows {
+				return nil, errExplodeMaxRows(maxRows)
+			}
+			for _, v := range items {
+				out = append(out, setPathValue(o, path, v))
+			}
+		}
+		input = out
+	}
+	return input, nil
+}
+
+func getPathValue(input map[string]any, path []string) (any, bool) {
+	var current any = input
+	for _, key := range path {
+		o, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = o[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setPathValue returns a copy of the input with the value set at the path. Only the objects along the path are copied
+func setPathValue(input map[string]any, path []string, value any) map[string]any {
+	out := make(map[string]any, len(input))
+	for k, v := range input {
+		out[k] = v
+	}
+	if len(path) == 1 {
+		out[path[0]] = value
+		return out
+	}
+	child, _ := input[path[0]].(map[string]any)
+	if child == nil {
+		child = map[string]any{}
+	}
+	out[path[0]] = setPathValue(child, path[1:], value)
+	return out
+}
@@ -0,0 +1,72 @@
+package anyframer_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+)
+
+func TestToFrame_Explode(t *testing.T) {
+	input := `{ "deployments" : [
+		{ "uid" : "d1", "aliases" : ["a.com","b.com"], "meta" : { "regions" : ["eu","us"] } },
+		{ "uid" : "d2", "aliases" : [], "meta" : { "regions" : ["ap"] } },
+		{ "uid" : "d3", "meta" : {} }
+	] }`
+	t.Run("explode outer", testToFrame(testInputs{
+		input:  input,
+		framer: Framer{RootSelector: "deployments", Explode: anyframer.ExplodeOptions{Selectors: []string{"aliases"}}},
+	}))
+	t.Run("explode inner", testToFrame(testInputs{
+		input:  input,
+		framer: Framer{RootSelector: "deployments", Explode: anyframer.ExplodeOptions{Selectors: []string{"aliases"}, Mode: anyframer.ExplodeModeInner}},
+	}))
+	t.Run("explode multiple selectors with columns", testToFrame(testInputs{
+		input: input,
+		framer: Framer{
+			RootSelector: "deployments",
+			Explode:      anyframer.ExplodeOptions{Selectors: []string{"aliases", "meta.regions"}},
+			Columns: []anyframer.Column{
+				{Selector: "uid", Format: anyframer.ColumnFormatString},
+				{Selector: "aliases", Alias: "alias", Format: anyframer.ColumnFormatString},
+				{Selector: "meta.regions", Alias: "region", Format: anyframer.ColumnFormatString},
+			},
+		},
+	}))
+	t.Run("explode array of objects", testToFrame(testInputs{
+		input:  `[{ "name" : "foo", "checks" : [{ "region" : "eu", "up" : true },{ "region" : "us", "up" : false }] }]`,
+		framer: Framer{Explode: anyframer.ExplodeOptions{Selectors: []string{"checks"}}, Columns: []anyframer.Column{{Selector: "name", Format: anyframer.ColumnFormatString}, {Selector: "checks.region", Alias: "region", Format: anyframer.ColumnFormatString}, {Selector: "checks.up", Alias: "up", Format: anyframer.ColumnFormatBoolean}}},
+	}))
+}
+
+func TestToFrame_ExplodeMaxRows(t *testing.T) {
+	values := make([]string, 100)
+	for i := range values {
+		values[i] = `"v"`
+	}
+	array := "[" + strings.Join(values, ",") + "]"
+	input := `[{ "a" : ` + array + `, "b" : ` + array + `, "c" : ` + array + ` }]`
+	t.Run("cartesian explode should be limited by default", testToFrame(testInputs{
+		input:   input,
+		framer:  Framer{Explode: anyframer.ExplodeOptions{Selectors: []string{"a", "b", "c"}}},
+		wantErr: errors.New("explode exceeded the maximum of 100000 rows"),
+	}))
+	t.Run("max rows should be respected", testToFrame(testInputs{
+		input:   input,
+		framer:  Framer{Explode: anyframer.ExplodeOptions{Selectors: []string{"a", "b"}, MaxRows: 1000}},
+		wantErr: errors.New("explode exceeded the maximum of 1000 rows"),
+	}))
+	t.Run("max rows should be respected across the streamed chunks", func(t *testing.T) {
+		framer := Framer{Explode: anyframer.ExplodeOptions{Selectors: []string{"a"}, MaxRows: 2500}}
+		_, err := framer.ToFrameFromReader(strings.NewReader("[" + strings.Repeat(`{ "a" : ["x","y"] },`, 1500) + `{ "a" : [] }]`))
+		require.EqualError(t, err, "explode exceeded the maximum of 2500 rows")
+	})
+	t.Run("rows within the limit should be exploded", func(t *testing.T) {
+		framer := Framer{Explode: anyframer.ExplodeOptions{Selectors: []string{"a", "b"}}}
+		frame, err := framer.ToFrame(input)
+		require.Nil(t, err)
+		require.Equal(t, 10000, frame.Rows())
+	})
+}
@@ -0,0 +1,172 @@
+package anyframer
+
+import (
+	"fmt"
+)
+
+// FlattenArrayMode ...
+type FlattenArrayMode string
+
+const (
+	// FlattenArrayModeStringify keeps the arrays as json string
+	FlattenArrayModeStringify FlattenArrayMode = "stringify"
+	// FlattenArrayModeExplode creates a row per array item
+	FlattenArrayModeExplode FlattenArrayMode = "explode"
+	// FlattenArrayModeIndex creates a column per array item such as tags.0, tags.1
+	FlattenArrayModeIndex FlattenArrayMode = "index"
+)
+
+const (
+	defaultFlattenSeparator = "."
+	defaultFlattenMaxRows   = 100000
+)
+
+// FlattenOptions ...
+// Nested objects are flattened into columns such as owner.login. MaxDepth 0 flattens all the levels.
+// Flattening applies only when no Columns are defined. Exploding multiple arrays multiplies the rows,
+// so flatten fails when the rows exceed MaxRows (default 100000)
+type FlattenOptions struct {
+	Enabled   bool             `json:"enabled,omitempty"`
+	MaxDepth  int              `json:"maxDepth,omitempty"`
+	Separator string           `json:"separator,omitempty"`
+	Arrays    FlattenArrayMode `json:"arrays,omitempty"`
+	MaxRows   int              `json:"maxRows,omitempty"`
+}
+
+func (options FlattenOptions) maxRows() int {
+	if options.MaxRows <= 0 {
+		return defaultFlattenMaxRows
+	}
+	return options.MaxRows
+}
+
+func errFlattenMaxRows(maxRows int) error {
+	return fmt.Errorf("flatten exceeded the maximum of %d rows", maxRows)
+}
+
+type flattener struct {
+	maxDepth  int
+	maxRows   int
+	separator string
+	arrays    FlattenArrayMode
+}
+
+func newFlattener(options FlattenOptions) flattener {
+	f := flattener{maxDepth: options.MaxDepth, maxRows: options.maxRows(), separator: options.Separator, arrays: options.Arrays}
+	if f.separator == "" {
+		f.separator = defaultFlattenSeparator
+	}
+	if f.arrays == "" {
+		f.arrays = FlattenArrayModeStringify
+	}
+	return f
+}
+
+// flattenSlice flattens each object of the input. When arrays are exploded, an object can produce more than one row
+func flattenSlice(input []any, options FlattenOptions) ([]any, error) {
+	f := newFlattener(options)
+	out := []any{}
+	for _, item := range input {
+		o, ok := item.(map[string]any)
+		if !ok {
+			out = append(out, item)
+			continue
+		}
+		rows, err := f.flatten(o, "", 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(out)+len(rows) > f.maxRows {
+			return nil, errFlattenMaxRows(f.maxRows)
+		}
+		for _, row := range rows {
+			out = append(out, row)
+		}
+	}
+	return out, nil
+}
+
+// flatten returns the flattened rows of the object. Only exploded arrays produce more than one row
+func (f flattener) flatten(input map[string]any, prefix string, depth int) ([]map[string]any, error) {
+	rows := []map[string]any{{}}
+	for _, key := range sortedKeys(input) {
+		name := key
+		if prefix != "" {
+			name = prefix + f.separator + key
+		}
+		values, err := f.flattenValue(input[key], name, depth)
+		if err != nil {
+			return nil, err
+		}
+		if rows, err = f.cross(rows, values); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+func (f flattener) flattenValue(value any, name string, depth int) ([]map[string]any, error) {
+	canFlatten := f.maxDepth <= 0 || depth <= f.maxDepth
+	switch v := value.(type) {
+	case map[string]any:
+		if canFlatten && len(v) > 0 {
+			return f.flatten(v, name, depth+1)
+		}
+	case []any:
+		if !canFlatten {
+			break
+		}
+		switch f.arrays {
+		case FlattenArrayModeIndex:
+			rows := []map[string]any{{}}
+			for idx, item := range v {
+				values, err := f.flattenValue(item, fmt.Sprintf("%s%s%d", name, f.separator, idx), depth+1)
+				if err != nil {
+					return nil, err
+				}
+				if rows, err = f.cross(rows, values); err != nil {
+					return nil, err
+				}
+			}
+			return rows, nil
+		case FlattenArrayModeExplode:
+			if len(v) == 0 {
+				return []map[string]any{{name: nil}}, nil
+			}
+			rows := []map[string]any{}
+			for _, item := range v {
+				values, err := f.flattenValue(item, name, depth)
+				if err != nil {
+					return nil, err
+				}
+				if len(rows)+len(values) > f.maxRows {
+					return nil, errFlattenMaxRows(f.maxRows)
+				}
+				rows = append(rows, values...)
+			}
+			return rows, nil
+		}
+	}
+	return []map[string]any{{name: value}}, nil
+}
+
+// cross returns the cartesian product of the rows. The product is checked against the max rows before allocating
+func (f flattener) cross(left []map[string]any, right []map[string]any) ([]map[string]any, error) {
+	if len(right) > 0 && len(left) > f.maxRows/len(right) {
+		return nil, errFlattenMaxRows(f.maxRows)
+	}
+	out := make([]map[string]any, 0, len(left)*len(right))
+	for _, l := range left {
+		for _, r := range right {
+			row := make(map[string]any, len(l)+len(r))
+			for k, v := range l {
+				row[k] = v
+			}
+			for k, v := range r {
+				row[k] = v
+			}
+			out = append(out, row)
+		}
+	}
+	return out, nil
+}
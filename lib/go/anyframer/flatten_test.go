@@ -0,0 +1,79 @@
+package anyframer_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+)
+
+func TestToFrame_Flatten(t *testing.T) {
+	input := `[
+		{ "name" : "foo", "owner" : { "login" : "alice", "address" : { "city" : "london" } }, "tags" : ["a","b"] },
+		{ "name" : "bar", "owner" : { "login" : "bob", "address" : { "city" : "chennai" } }, "tags" : [] }
+	]`
+	t.Run("flatten disabled", testToFrame(testInputs{
+		input: input,
+	}))
+	t.Run("flatten", testToFrame(testInputs{
+		input:  input,
+		framer: Framer{FlattenOptions: anyframer.FlattenOptions{Enabled: true}},
+	}))
+	t.Run("flatten with max depth and separator", testToFrame(testInputs{
+		input:  input,
+		framer: Framer{FlattenOptions: anyframer.FlattenOptions{Enabled: true, MaxDepth: 1, Separator: "_"}},
+	}))
+	t.Run("flatten with array index", testToFrame(testInputs{
+		input:  input,
+		framer: Framer{FlattenOptions: anyframer.FlattenOptions{Enabled: true, Arrays: anyframer.FlattenArrayModeIndex}},
+	}))
+	t.Run("flatten with array explode", testToFrame(testInputs{
+		input:  input,
+		framer: Framer{FlattenOptions: anyframer.FlattenOptions{Enabled: true, Arrays: anyframer.FlattenArrayModeExplode}},
+	}))
+	t.Run("flatten with array of objects explode", testToFrame(testInputs{
+		input:  `{ "monitors" : [{ "name" : "foo", "regions" : [{ "id" : "eu", "up" : true },{ "id" : "us", "up" : false }] }] }`,
+		framer: Framer{RootSelector: "monitors", FlattenOptions: anyframer.FlattenOptions{Enabled: true, Arrays: anyframer.FlattenArrayModeExplode}},
+	}))
+	t.Run("flatten should be ignored with columns", testToFrame(testInputs{
+		input:  input,
+		framer: Framer{FlattenOptions: anyframer.FlattenOptions{Enabled: true}, Columns: []anyframer.Column{{Selector: "owner.login", Alias: "login", Format: anyframer.ColumnFormatString}}},
+	}))
+}
+
+func TestToFrame_FlattenMaxRows(t *testing.T) {
+	values := make([]string, 100)
+	for i := range values {
+		values[i] = `"v"`
+	}
+	array := "[" + strings.Join(values, ",") + "]"
+	input := `[{ "a" : ` + array + `, "b" : ` + array + `, "c" : ` + array + ` }]`
+	t.Run("cartesian flatten should be limited by default", testToFrame(testInputs{
+		input:   input,
+		framer:  Framer{FlattenOptions: anyframer.FlattenOptions{Enabled: true, Arrays: anyframer.FlattenArrayModeExplode}},
+		wantErr: errors.New("flatten exceeded the maximum of 100000 rows"),
+	}))
+	t.Run("max rows should be respected", testToFrame(testInputs{
+		input:   `[{ "a" : ` + array + `, "b" : ` + array + ` }]`,
+		framer:  Framer{FlattenOptions: anyframer.FlattenOptions{Enabled: true, Arrays: anyframer.FlattenArrayModeExplode, MaxRows: 1000}},
+		wantErr: errors.New("flatten exceeded the maximum of 1000 rows"),
+	}))
+	t.Run("max rows should be respected across the items", testToFrame(testInputs{
+		input:   "[" + strings.Repeat(`{ "a" : ["x","y"] },`, 10) + `{ "a" : [] }]`,
+		framer:  Framer{FlattenOptions: anyframer.FlattenOptions{Enabled: true, Arrays: anyframer.FlattenArrayModeExplode, MaxRows: 20}},
+		wantErr: errors.New("flatten exceeded the maximum of 20 rows"),
+	}))
+	t.Run("max rows should be respected across the streamed chunks", func(t *testing.T) {
+		framer := Framer{FlattenOptions: anyframer.FlattenOptions{Enabled: true, Arrays: anyframer.FlattenArrayModeExplode, MaxRows: 2500}}
+		_, err := framer.ToFrameFromReader(strings.NewReader("[" + strings.Repeat(`{ "a" : ["x","y"] },`, 1500) + `{ "a" : [] }]`))
+		require.EqualError(t, err, "flatten exceeded the maximum of 2500 rows")
+	})
+	t.Run("rows within the limit should be flattened", func(t *testing.T) {
+		framer := Framer{FlattenOptions: anyframer.FlattenOptions{Enabled: true, Arrays: anyframer.FlattenArrayModeExplode}}
+		frame, err := framer.ToFrame(`[{ "a" : ` + array + `, "b" : ` + array + ` }]`)
+		require.Nil(t, err)
+		require.Equal(t, 10000, frame.Rows())
+	})
+}
@@ -1,24 +1,39 @@
 package anyframer
 
 import (
-	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	xj "github.com/basgys/goxml2json"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/csvframer"
+	"gopkg.in/yaml.v3"
 )
 
 func (options *AnyFramer) toJSONObject(input string) (any, error) {
-	input = strings.TrimSpace(input)
-	if input == "" {
+	if strings.TrimSpace(input) == "" {
 		return nil, errors.New("invalid/empty input")
 	}
+	if options.InputType == InputTypeXLSX {
+		// xlsx is a binary format and should not be trimmed
+		return toObjectFromXLSXString(input, *options)
+	}
+	// encoded csv is decoded by the csv parser and should not be trimmed
+	if options.CSVOptions.Encoding == "" || (options.InputType != InputTypeCSV && options.InputType != InputTypeTSV) {
+		input = strings.TrimSpace(input)
+	}
 	switch options.InputType {
 	case InputTypeJSON:
 		return toObjectFromJSONString(input, *options)
+	case InputTypeNDJSON:
+		return toObjectFromNDJSONString(input, *options)
+	case InputTypeYAML:
+		return toObjectFromYAMLString(input, *options)
+	case InputTypeTOML:
+		return toObjectFromTOMLString(input, *options)
 	case InputTypeTSV:
 		options.CSVOptions.Delimiter = "\t"
 		return toObjectFromCSVString(input, *options)
@@ -47,86 +62,88 @@ func toObjectFromJSONString(jsonString string, options AnyFramer) (jsonObject an
 	return jsonObject, nil
 }
 
-func toObjectFromCSVString(csvString string, options AnyFramer) (jsonObject any, err error) {
+func toObjectFromNDJSONString(ndjsonString string, options AnyFramer) (jsonObject any, err error) {
 	out := []any{}
-	delimiter := options.CSVOptions.Delimiter
-	if delimiter == "" {
-		delimiter = ","
-	}
-	if len(options.CSVOptions.Headers) > 0 {
-		headers := []string{}
-		for _, h := range options.CSVOptions.Headers {
-			h = strings.TrimSpace(h)
-			if strings.HasPrefix(h, `"`) && strings.HasSuffix(h, `"`) {
-				headers = append(headers, h)
+	for idx, line := range strings.Split(ndjsonString, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var item any
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			if options.NDJSONOptions.SkipMalformedLines {
 				continue
 			}
-			headers = append(headers, `"`+h+`"`)
+			return nil, fmt.Errorf("error reading ndjson line %d. %w", idx+1, err)
 		}
-		csvString = strings.Join(headers, delimiter) + "\n" + csvString
-	}
-	r := csv.NewReader(strings.NewReader(csvString))
-	r.LazyQuotes = true
-	if options.CSVOptions.Comment != "" {
-		r.Comment = rune(options.CSVOptions.Comment[0])
-	}
-	if delimiter != "" {
-		r.Comma = rune(delimiter[0])
-	}
-	if options.CSVOptions.RelaxColumnCount {
-		r.FieldsPerRecord = -1
+		out = append(out, item)
 	}
-	parsedCSV := [][]string{}
+	return out, nil
+}
+
+func toObjectFromYAMLString(yamlString string, options AnyFramer) (jsonObject any, err error) {
+	documents := []any{}
+	decoder := yaml.NewDecoder(strings.NewReader(yamlString))
 	for {
-		record, err := r.Read()
+		var document any
+		err := decoder.Decode(&document)
 		if err == io.EOF {
 			break
 		}
-		if err == nil {
-			parsedCSV = append(parsedCSV, record)
-			continue
-		}
-		if !options.CSVOptions.SkipLinesWithError {
-			return nil, fmt.Errorf("error reading csv response. %w, %v", err, record)
+		if err != nil {
+			return nil, fmt.Errorf("error reading yaml. %w", err)
 		}
+		documents = append(documents, normalizeObject(document))
 	}
-	if len(parsedCSV) == 0 {
-		return nil, errors.New("invalid/empty csv")
-	}
-	header := []string{}
-	records := [][]string{}
-	if !options.CSVOptions.NoHeaders {
-		header = parsedCSV[0]
-		for idx, hItem := range header {
-			for _, col := range options.Columns {
-				if col.Selector == hItem && col.Alias != "" {
-					header[idx] = col.Alias
-				}
-			}
-		}
-		records = parsedCSV[1:]
+	if len(documents) == 0 {
+		return nil, errors.New("invalid/empty yaml")
 	}
-	if options.CSVOptions.NoHeaders {
-		records = parsedCSV
-		if len(records) > 0 {
-			for i := 0; i < len(records[0]); i++ {
-				header = append(header, fmt.Sprintf("%d", i+1))
-			}
-		}
+	if len(documents) == 1 {
+		return documents[0], nil
 	}
-	for _, row := range records {
-		item := map[string]any{}
-		for hID, h := range header {
-			if hID < len(row) {
-				item[h] = row[hID]
-			}
+	return documents, nil
+}
+
+func toObjectFromTOMLString(tomlString string, options AnyFramer) (jsonObject any, err error) {
+	document := map[string]any{}
+	if _, err := toml.Decode(tomlString, &document); err != nil {
+		return nil, fmt.Errorf("error reading toml. %w", err)
+	}
+	return normalizeObject(document), nil
+}
+
+func toObjectFromCSVString(csvString string, options AnyFramer) (jsonObject any, err error) {
+	aliases := map[string]string{}
+	for _, col := range options.Columns {
+		if col.Alias != "" {
+			aliases[col.Selector] = col.Alias
 		}
-		out = append(out, item)
+	}
+	header, out, err := csvframer.Parse(csvString, csvframer.ParseOptions{
+		Delimiter:          options.CSVOptions.Delimiter,
+		Quote:              options.CSVOptions.Quote,
+		Comment:            options.CSVOptions.Comment,
+		Encoding:           csvframer.Encoding(options.CSVOptions.Encoding),
+		SkipRows:           options.CSVOptions.SkipRows,
+		RelaxColumnCount:   options.CSVOptions.RelaxColumnCount,
+		SkipLinesWithError: options.CSVOptions.SkipLinesWithError,
+		NoHeaders:          options.CSVOptions.NoHeaders,
+		Headers:            options.CSVOptions.Headers,
+		Aliases:            aliases,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if options.CSVOptions.InferTypes {
+		return inferCSVTypes(header, out, options.CSVOptions), nil
 	}
 	return out, nil
 }
 
 func toObjectFromHTMLString(htmlString string, options AnyFramer) (jsonObject any, err error) {
+	if options.HTMLOptions.Mode == HTMLModeTable {
+		return toObjectFromHTMLTable(htmlString, options)
+	}
 	return toObjectFromXMLString(htmlString, options)
 }
 
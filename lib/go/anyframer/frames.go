@@ -0,0 +1,188 @@
+package anyframer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// SplitMode ...
+type SplitMode string
+
+const (
+	// SplitModeNone returns a single frame
+	SplitModeNone SplitMode = "none"
+	// SplitModeKeys returns one frame per array property of the root object
+	SplitModeKeys SplitMode = "keys"
+	// SplitModeGroupBy returns one frame per distinct value of the GroupBy selector
+	SplitModeGroupBy SplitMode = "groupBy"
+)
+
+// SplitOptions ...
+type SplitOptions struct {
+	Mode    SplitMode `json:"mode,omitempty"`
+	GroupBy string    `json:"groupBy,omitempty"`
+}
+
+const splitNullGroupName = "null"
+
+// ToFrames converts the given input into one or more frames based on the SplitOptions.
+// Split by keys of html input in table mode returns a frame per table. Frames are ordered by the key / group name (tables by document order)
+// and the RefID of the framer is applied to all the frames
+func (framerOptions *AnyFramer) ToFrames(input any) (data.Frames, error) {
+	if framerOptions.Name == "" {
+		framerOptions.Name = "response"
+	}
+	frames := data.Frames{}
+	switch framerOptions.SplitOptions.Mode {
+	case SplitModeKeys, SplitModeGroupBy:
+		if framerOptions.SplitOptions.Mode == SplitModeKeys && framerOptions.isHTMLTableInput(input) {
+			// each html table is split into its own frame
+			htmlString, _ := input.(string)
+			if b, ok := input.([]byte); ok {
+				htmlString = string(b)
+			}
+			htmlFrames, err := framerOptions.ToFramesFromHTMLTables(htmlString)
+			if err != nil {
+				return nil, err
+			}
+			frames = htmlFrames
+			break
+		}
+		object, err := framerOptions.toSplitObject(input)
+		if err != nil {
+			return nil, err
+		}
+		if framerOptions.SplitOptions.Mode == SplitModeKeys {
+			frames, err = splitByKeys(object, *framerOptions)
+		} else {
+			frames, err = splitByGroup(object, *framerOptions)
+		}
+		if err != nil {
+			return nil, err
+		}
+	case "", SplitModeNone:
+		frame, err := framerOptions.ToFrame(input)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+	default:
+		return nil, fmt.Errorf("invalid split mode %q", framerOptions.SplitOptions.Mode)
+	}
+	for _, frame := range frames {
+		frame.RefID = framerOptions.RefID
+	}
+	return frames, nil
+}
+
+// isHTMLTableInput reports whether the input is a html string or bytes framed using the html table mode
+func (framerOptions *AnyFramer) isHTMLTableInput(input any) bool {
+	if framerOptions.HTMLOptions.Mode != HTMLModeTable {
+		return false
+	}
+	switch t := input.(type) {
+	case string:
+		if framerOptions.InputType == "" || framerOptions.InputType == InputTypeUnknown {
+			framerOptions.InputType = framerOptions.GuessType(t)
+		}
+	case []byte:
+		if framerOptions.InputType == "" || framerOptions.InputType == InputTypeUnknown {
+			framerOptions.InputType = guessInputTypeFromBytes(t)
+		}
+	default:
+		return false
+	}
+	return framerOptions.InputType == InputTypeHTML
+}
+
+func (framerOptions *AnyFramer) toSplitObject(input any) (any, error) {
+	switch t := input.(type) {
+	case []byte:
+		return framerOptions.toSplitObject(string(t))
+	case string:
+		if framerOptions.InputType == "" || framerOptions.InputType == InputTypeUnknown {
+			framerOptions.InputType = framerOptions.GuessType(t)
+		}
+		if isBinaryInputType(framerOptions.InputType) {
+			return nil, fmt.Errorf("split mode %q is not supported for %s input", framerOptions.SplitOptions.Mode, framerOptions.InputType)
+		}
+		object, err := framerOptions.toJSONObject(t)
+		if err != nil {
+			return nil, err
+		}
+		return applySelector(object, framerOptions.RootSelector)
+	default:
+		return applySelector(input, framerOptions.RootSelector)
+	}
+}
+
+// splitByKeys returns a frame per array property of the object. Each frame is built the same way as ToFrame builds the frame of an array
+func splitByKeys(object any, options AnyFramer) (data.Frames, error) {
+	o, ok := object.(map[string]any)
+	if !ok {
+		return nil, errors.New("invalid input. split by keys requires an object")
+	}
+	frames := data.Frames{}
+	for _, key := range sortedKeys(o) {
+		items, ok := o[key].([]any)
+		if !ok {
+			continue
+		}
+		frame, err := options.toFrameFromSlice(key, items)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+	}
+	if len(frames) == 0 {
+		return nil, errors.New("invalid input. no array properties found to split by keys")
+	}
+	return frames, nil
+}
+
+// splitByGroup returns a frame per distinct value of the group by selector
+func splitByGroup(object any, options AnyFramer) (data.Frames, error) {
+	if options.SplitOptions.GroupBy == "" {
+		return nil, errors.New("invalid/empty group by selector")
+	}
+	items, ok := object.([]any)
+	if !ok {
+		return nil, errors.New("invalid input. split by group requires an array")
+	}
+	expr, err := compileSelector(options.SplitOptions.GroupBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group by selector %q. %w", options.SplitOptions.GroupBy, err)
+	}
+	if len(options.Explode.Selectors) > 0 {
+		if items, err = explodeSlice(items, options.Explode); err != nil {
+			return nil, err
+		}
+	}
+	groups := map[string][]any{}
+	for _, item := range items {
+		name := splitNullGroupName
+		if value, err := expr.Eval(item); err == nil && value != nil && fmt.Sprintf("%v", value) != "" {
+			name = fmt.Sprintf("%v", value)
+		}
+		groups[name] = append(groups[name], item)
+	}
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// items are already exploded before grouping
+	options.Explode = ExplodeOptions{}
+	frames := data.Frames{}
+	for _, name := range names {
+		frame, err := options.toFrameFromSlice(name, groups[name])
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
@@ -0,0 +1,82 @@
+package anyframer_test
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/experimental"
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+)
+
+func TestToFrames(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   any
+		framer  Framer
+		wantErr string
+	}{
+		{
+			name:   "without split mode",
+			input:  `[{ "name" : "foo" },{ "name" : "bar" }]`,
+			framer: Framer{RefID: "A"},
+		},
+		{
+			name:   "split by keys",
+			input:  `{ "projects" : [{ "id" : "p1" },{ "id" : "p2" }], "deployments" : [{ "uid" : "d1", "state" : "READY" }], "pagination" : { "next" : 1 } }`,
+			framer: Framer{RefID: "A", SplitOptions: anyframer.SplitOptions{Mode: anyframer.SplitModeKeys}},
+		},
+		{
+			name:   "split by keys with root selector",
+			input:  map[string]any{"data": map[string]any{"b": []any{map[string]any{"id": 1.0}}, "a": []any{"foo", "bar"}}},
+			framer: Framer{RefID: "B", RootSelector: "data", SplitOptions: anyframer.SplitOptions{Mode: anyframer.SplitModeKeys}},
+		},
+		{
+			name:   "split by group",
+			input:  "time,host,value\n2023-01-01T00:00:00Z,b,1\n2023-01-01T00:00:00Z,a,2\n2023-01-01T00:01:00Z,b,3\n2023-01-01T00:01:00Z,,4",
+			framer: Framer{RefID: "A", SplitOptions: anyframer.SplitOptions{Mode: anyframer.SplitModeGroupBy, GroupBy: "host"}, Columns: []anyframer.Column{{Selector: "time", Format: anyframer.ColumnFormatTimeStamp}, {Selector: "value", Format: anyframer.ColumnFormatNumber}}},
+		},
+		{
+			name:   "split by keys with columns",
+			input:  `{ "up" : [{ "id" : "1", "name" : "foo" }], "down" : [{ "id" : "2", "name" : "bar" }] }`,
+			framer: Framer{RefID: "A", SplitOptions: anyframer.SplitOptions{Mode: anyframer.SplitModeKeys}, Columns: []anyframer.Column{{Selector: "id", Alias: "ID", Format: anyframer.ColumnFormatNumber}}},
+		},
+		{
+			name:   "split by keys with html tables",
+			input:  `<table id="users"><tr><th>name</th><th>age</th></tr><tr><td>foo</td><td>12</td></tr></table><table><caption>pets</caption><tr><th>name</th><th>age</th></tr><tr><td>bar</td><td>3</td></tr></table>`,
+			framer: Framer{RefID: "A", InputType: anyframer.InputTypeHTML, HTMLOptions: anyframer.HTMLOptions{Mode: anyframer.HTMLModeTable}, SplitOptions: anyframer.SplitOptions{Mode: anyframer.SplitModeKeys}, Columns: []anyframer.Column{{Selector: "name", Format: anyframer.ColumnFormatString}, {Selector: "age", Format: anyframer.ColumnFormatNumber}}},
+		},
+		{
+			name:    "split by keys without object should throw error",
+			input:   `[1,2]`,
+			framer:  Framer{SplitOptions: anyframer.SplitOptions{Mode: anyframer.SplitModeKeys}},
+			wantErr: "invalid input. split by keys requires an object",
+		},
+		{
+			name:    "split by group without group by should throw error",
+			input:   `[1,2]`,
+			framer:  Framer{SplitOptions: anyframer.SplitOptions{Mode: anyframer.SplitModeGroupBy}},
+			wantErr: "invalid/empty group by selector",
+		},
+		{
+			name:    "invalid split mode should throw error",
+			input:   `[1,2]`,
+			framer:  Framer{SplitOptions: anyframer.SplitOptions{Mode: "foo"}},
+			wantErr: `invalid split mode "foo"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames, err := tt.framer.ToFrames(tt.input)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			for _, frame := range frames {
+				require.Equal(t, tt.framer.RefID, frame.RefID)
+			}
+			experimental.CheckGoldenJSONResponse(t, "testdata/golden", t.Name(), &backend.DataResponse{Frames: frames}, updateGoldenFile)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package anyframer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+type htmlTable struct {
+	name string
+	rows []any
+}
+
+// ToFramesFromHTMLTables converts every table selected by the HTMLOptions.TableSelector into a frame
+func (framerOptions *AnyFramer) ToFramesFromHTMLTables(input string) (data.Frames, error) {
+	tables, err := getHTMLTables(input, framerOptions.HTMLOptions)
+	if err != nil {
+		return nil, err
+	}
+	frames := data.Frames{}
+	for _, table := range tables {
+		options := *framerOptions
+		options.Name = table.name
+		frame, err := toFrameFromInterface(table.rows, options)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
+
+func toObjectFromHTMLTable(htmlString string, options AnyFramer) (jsonObject any, err error) {
+	tables, err := getHTMLTables(htmlString, options.HTMLOptions)
+	if err != nil {
+		return nil, err
+	}
+	index := options.HTMLOptions.TableIndex
+	if options.HTMLOptions.TableSelector != "" {
+		index = 0
+	}
+	if index < 0 || index >= len(tables) {
+		return nil, fmt.Errorf("invalid table index %d. %d table(s) found", index, len(tables))
+	}
+	return tables[index].rows, nil
+}
+
+func getHTMLTables(htmlString string, options HTMLOptions) ([]htmlTable, error) {
+	doc, err := html.Parse(strings.NewReader(htmlString))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing html. %w", err)
+	}
+	selector := "table"
+	if strings.TrimSpace(options.TableSelector) != "" {
+		selector = options.TableSelector
+	}
+	sel, err := cascadia.Compile(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid table selector %q. %w", selector, err)
+	}
+	tables := []htmlTable{}
+	for _, node := range sel.MatchAll(doc) {
+		if node.DataAtom != atom.Table {
+			continue
+		}
+		tables = append(tables, htmlTable{name: getHTMLTableName(node, len(tables)), rows: getHTMLTableRows(node)})
+	}
+	if len(tables) == 0 {
+		return nil, errors.New("no html tables found")
+	}
+	return tables, nil
+}
+
+func getHTMLTableName(table *html.Node, idx int) string {
+	for c := table.FirstChild; c != nil; c = c.NextSibling {
+		if c.DataAtom == atom.Caption {
+			if caption := getHTMLText(c); caption != "" {
+				return caption
+			}
+		}
+	}
+	for _, attr := range table.Attr {
+		if attr.Key == "id" && strings.TrimSpace(attr.Val) != "" {
+			return strings.TrimSpace(attr.Val)
+		}
+	}
+	return fmt.Sprintf("table %d", idx+1)
+}
+
+type htmlCell struct {
+	text     string
+	isHeader bool
+}
+
+// getHTMLTableRows builds the cell grid of the table, expanding colspan and rowspan,
+// and converts it into rows keyed by the header cells
+func getHTMLTableRows(table *html.Node) []any {
+	grid := [][]*htmlCell{}
+	headerRows := 0
+	rows := getHTMLTableRowNodes(table)
+	for rowIdx, tr := range rows {
+		for len(grid) <= rowIdx {
+			grid = append(grid, []*htmlCell{})
+		}
+		col := 0
+		allHeaders := true
+		for td := tr.FirstChild; td != nil; td = td.NextSibling {
+			if td.Type != html.ElementNode || (td.DataAtom != atom.Td && td.DataAtom != atom.Th) {
+				continue
+			}
+			for col < len(grid[rowIdx]) && grid[rowIdx][col] != nil {
+				col++
+			}
+			cell := &htmlCell{text: getHTMLText(td), isHeader: td.DataAtom == atom.Th}
+			allHeaders = allHeaders && cell.isHeader
+			colspan := getHTMLSpan(td, "colspan", maxHTMLColspan)
+			rowspan := getHTMLSpan(td, "rowspan", maxHTMLRowspan)
+			// rowspan stops at the last row of the table
+			for r := rowIdx; r < rowIdx+rowspan && r < len(rows); r++ {
+				for len(grid) <= r {
+					grid = append(grid, []*htmlCell{})
+				}
+				for c := col; c < col+colspan; c++ {
+					for len(grid[r]) <= c {
+						grid[r] = append(grid[r], nil)
+					}
+					grid[r][c] = cell
+				}
+			}
+			col += colspan
+		}
+		if allHeaders && headerRows == rowIdx && len(grid[rowIdx]) > 0 {
+			headerRows++
+		}
+	}
+	columnsCount := 0
+	for _, row := range grid {
+		if len(row) > columnsCount {
+			columnsCount = len(row)
+		}
+	}
+	headers := make([]string, columnsCount)
+	for c := 0; c < columnsCount; c++ {
+		parts := []string{}
+		for r := 0; r < headerRows; r++ {
+			if c < len(grid[r]) && grid[r][c] != nil && grid[r][c].text != "" {
+				if len(parts) == 0 || parts[len(parts)-1] != grid[r][c].text {
+					parts = append(parts, grid[r][c].text)
+				}
+			}
+		}
+		headers[c] = strings.Join(parts, " ")
+		if headers[c] == "" {
+			headers[c] = fmt.Sprintf("%d", c+1)
+		}
+	}
+	headers = uniqueHeaders(headers)
+	out := []any{}
+	for _, row := range grid[headerRows:] {
+		if len(row) == 0 {
+			continue
+		}
+		item := map[string]any{}
+		for c, h := range headers {
+			if c < len(row) && row[c] != nil {
+				item[h] = row[c].text
+				continue
+			}
+			item[h] = nil
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// getHTMLTableRowNodes returns the rows of the table, skipping the rows of the nested tables
+func getHTMLTableRowNodes(table *html.Node) []*html.Node {
+	rows := []*html.Node{}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch c.DataAtom {
+			case atom.Tr:
+				rows = append(rows, c)
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				walk(c)
+			}
+		}
+	}
+	walk(table)
+	return rows
+}
+
+// maximum colspan and rowspan values, same as the browsers clamp them
+const (
+	maxHTMLColspan = 1000
+	maxHTMLRowspan = 65534
+)
+
+func getHTMLSpan(n *html.Node, key string, max int) int {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			if v, err := strconv.Atoi(strings.TrimSpace(attr.Val)); err == nil && v > 0 {
+				if v > max {
+					return max
+				}
+				return v
+			}
+		}
+	}
+	return 1
+}
+
+func getHTMLText(n *html.Node) string {
+	sb := &strings.Builder{}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+			sb.WriteString(" ")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+func uniqueHeaders(headers []string) []string {
+	out := make([]string, len(headers))
+	names := map[string]bool{}
+	for _, h := range headers {
+		names[h] = true
+	}
+	seen := map[string]bool{}
+	for i, h := range headers {
+		name := h
+		if seen[h] {
+			for suffix := 2; names[name]; suffix++ {
+				name = fmt.Sprintf("%s %d", h, suffix)
+			}
+			names[name] = true
+		}
+		seen[h] = true
+		out[i] = name
+	}
+	return out
+}
@@ -1,6 +1,7 @@
 package anyframer
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"strings"
@@ -22,6 +23,18 @@ const (
 	InputTypeHTML InputType = "html"
 	// InputTypeXML ...
 	InputTypeXML InputType = "xml"
+	// InputTypeNDJSON ...
+	InputTypeNDJSON InputType = "ndjson"
+	// InputTypeYAML ...
+	InputTypeYAML InputType = "yaml"
+	// InputTypeTOML ...
+	InputTypeTOML InputType = "toml"
+	// InputTypeArrow ...
+	InputTypeArrow InputType = "arrow"
+	// InputTypeParquet ...
+	InputTypeParquet InputType = "parquet"
+	// InputTypeXLSX ...
+	InputTypeXLSX InputType = "xlsx"
 )
 
 // GuessType guesses the framer type from input string and other framer parameters such as RawURL, headers
@@ -49,6 +62,9 @@ func guessInputTypeFromURL(rawURL string) InputType {
 
 func guessInputTypeFromFileName(fileName string) InputType {
 	fileName = strings.ToLower(fileName)
+	if strings.HasSuffix(fileName, ".ndjson") || strings.HasSuffix(fileName, ".jsonl") {
+		return InputTypeNDJSON
+	}
 	if strings.HasSuffix(fileName, ".json") {
 		return InputTypeJSON
 	}
@@ -64,11 +80,29 @@ func guessInputTypeFromFileName(fileName string) InputType {
 	if strings.HasSuffix(fileName, ".html") {
 		return InputTypeHTML
 	}
+	if strings.HasSuffix(fileName, ".yaml") || strings.HasSuffix(fileName, ".yml") {
+		return InputTypeYAML
+	}
+	if strings.HasSuffix(fileName, ".toml") {
+		return InputTypeTOML
+	}
+	if strings.HasSuffix(fileName, ".arrow") || strings.HasSuffix(fileName, ".arrows") || strings.HasSuffix(fileName, ".feather") {
+		return InputTypeArrow
+	}
+	if strings.HasSuffix(fileName, ".parquet") {
+		return InputTypeParquet
+	}
+	if strings.HasSuffix(fileName, ".xlsx") {
+		return InputTypeXLSX
+	}
 	return InputTypeUnknown
 }
 
 func guessInputTypeFromResponseHeaders(headers http.Header) InputType {
 	contentType := strings.ToLower(headers.Get("Content-Type"))
+	if strings.HasPrefix(contentType, "application/x-ndjson") || strings.HasPrefix(contentType, "application/jsonl") {
+		return InputTypeNDJSON
+	}
 	if strings.HasPrefix(contentType, "application/json") {
 		return InputTypeJSON
 	}
@@ -81,11 +115,32 @@ func guessInputTypeFromResponseHeaders(headers http.Header) InputType {
 	if strings.HasPrefix(contentType, "application/xml") || strings.HasPrefix(contentType, "text/xml") {
 		return InputTypeXML
 	}
+	if strings.HasPrefix(contentType, "application/yaml") || strings.HasPrefix(contentType, "application/x-yaml") || strings.HasPrefix(contentType, "text/yaml") || strings.HasPrefix(contentType, "text/x-yaml") {
+		return InputTypeYAML
+	}
+	if strings.HasPrefix(contentType, "application/toml") {
+		return InputTypeTOML
+	}
+	if strings.HasPrefix(contentType, "application/vnd.apache.arrow") {
+		return InputTypeArrow
+	}
+	if strings.HasPrefix(contentType, "application/vnd.apache.parquet") || strings.HasPrefix(contentType, "application/x-parquet") {
+		return InputTypeParquet
+	}
+	if strings.HasPrefix(contentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet") {
+		return InputTypeXLSX
+	}
 	return InputTypeUnknown
 }
 
 func guessInputTypeFromInput(input string) InputType {
+	if o := guessInputTypeFromBytes([]byte(input)); o != InputTypeUnknown {
+		return o
+	}
 	input = strings.TrimSpace(strings.ToLower(input))
+	if isNDJSON(input) {
+		return InputTypeNDJSON
+	}
 	if strings.HasPrefix(input, "{") && strings.HasSuffix(input, "}") {
 		return InputTypeJSON
 	}
@@ -98,6 +153,9 @@ func guessInputTypeFromInput(input string) InputType {
 	if strings.HasPrefix(input, "<") && strings.HasSuffix(input, ">") {
 		return InputTypeXML
 	}
+	if strings.HasPrefix(input, "---\n") || strings.HasPrefix(input, "%yaml") {
+		return InputTypeYAML
+	}
 	csvArray := strings.Split(input, "\n")
 	if len(csvArray) > 1 && strings.Contains(csvArray[0], "\t") {
 		return InputTypeTSV
@@ -107,3 +165,23 @@ func guessInputTypeFromInput(input string) InputType {
 	}
 	return InputTypeUnknown
 }
+
+// isNDJSON checks whether the input has more than one line and every non empty line is a valid json object or array
+func isNDJSON(input string) bool {
+	lines := strings.Split(input, "\n")
+	count := 0
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !((strings.HasPrefix(line, "{") && strings.HasSuffix(line, "}")) || (strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"))) {
+			return false
+		}
+		if !json.Valid([]byte(line)) {
+			return false
+		}
+		count++
+	}
+	return count > 1
+}
@@ -22,16 +22,26 @@ func TestGuessType(t *testing.T) {
 		{rawURL: "https://foo.com/bar"},
 		{rawURL: "https://foo.com/bar.json", want: anyframer.InputTypeJSON},
 		{rawURL: "https://foo.com/bar.json?something=nothing", want: anyframer.InputTypeJSON},
-		{rawURL: "foo.yaml"},
+		{rawURL: "foo.yaml", want: anyframer.InputTypeYAML},
+		{rawURL: "foo/bar.yml", want: anyframer.InputTypeYAML},
+		{rawURL: "https://foo.com/bar.toml", want: anyframer.InputTypeTOML},
 		{rawURL: "foo.json", want: anyframer.InputTypeJSON},
 		{rawURL: "foo/bar.csv", want: anyframer.InputTypeCSV},
+		{rawURL: "foo/bar.ndjson", want: anyframer.InputTypeNDJSON},
+		{rawURL: "https://foo.com/bar.jsonl?something=nothing", want: anyframer.InputTypeNDJSON},
 		{rawURL: "https://foo.com/bar", headers: http.Header{}},
 		{rawURL: "https://foo.com/bar", headers: map[string][]string{"something": {"nothing"}}},
 		{rawURL: "https://foo.com/bar", headers: map[string][]string{"Content-Type": {"nothing"}}},
 		{rawURL: "https://foo.com/bar", headers: map[string][]string{"Content-Type": {"application/json"}}, want: anyframer.InputTypeJSON},
+		{rawURL: "https://foo.com/bar", headers: map[string][]string{"Content-Type": {"application/x-ndjson"}}, want: anyframer.InputTypeNDJSON},
+		{rawURL: "https://foo.com/bar", headers: map[string][]string{"Content-Type": {"application/yaml"}}, want: anyframer.InputTypeYAML},
+		{rawURL: "https://foo.com/bar", headers: map[string][]string{"Content-Type": {"application/toml"}}, want: anyframer.InputTypeTOML},
 		{rawURL: "https://foo.com/bar", input: "hello"},
 		{rawURL: "https://foo.com/bar", input: " { \"foo\" : 123 } ", want: anyframer.InputTypeJSON},
 		{rawURL: "https://foo.com/bar", input: " [1,2,3] ", want: anyframer.InputTypeJSON},
+		{rawURL: "https://foo.com/bar", input: "{\n \"foo\" : 123 \n}", want: anyframer.InputTypeJSON},
+		{rawURL: "https://foo.com/bar", input: "{ \"foo\" : 123 }\n{ \"foo\" : 456 }\n", want: anyframer.InputTypeNDJSON},
+		{rawURL: "https://foo.com/bar", input: "[1,2]\n[3,4]", want: anyframer.InputTypeNDJSON},
 		{rawURL: "https://foo.com/bar", input: "a	b	c\n1	2	3", want: anyframer.InputTypeTSV},
 		{rawURL: "https://foo.com/bar", input: "a,b,c\n1,2,3", want: anyframer.InputTypeCSV},
 		{rawURL: "https://foo.com/bar", input: "<html ></html>", want: anyframer.InputTypeHTML},
@@ -41,6 +51,7 @@ func TestGuessType(t *testing.T) {
 		{rawURL: "https://foo.com/bar", input: "<xml ></xml>", want: anyframer.InputTypeXML},
 		{rawURL: "https://foo.com/bar", input: "<?xml ></xml>", want: anyframer.InputTypeXML},
 		{rawURL: "https://foo.com/bar", input: "<rss></rss>", want: anyframer.InputTypeXML},
+		{rawURL: "https://foo.com/bar", input: "---\nfoo: bar", want: anyframer.InputTypeYAML},
 	}
 	for _, tt := range tests {
 		want := tt.want
@@ -3,6 +3,9 @@ package anyframer_test
 import (
 	"errors"
 	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
 )
 
 func TestRootSelector(t *testing.T) {
@@ -20,3 +23,18 @@ func TestRootSelector(t *testing.T) {
 		framer: Framer{RootSelector: "users"},
 	}))
 }
+
+func TestRootSelector_MalformedSelector(t *testing.T) {
+	t.Run("malformed root selector should throw error instead of panic", func(t *testing.T) {
+		framer := Framer{RootSelector: "users["}
+		_, err := framer.ToFrame(`{ "users" : [] }`)
+		require.NotNil(t, err)
+		require.ErrorContains(t, err, `invalid root selector "users[".`)
+	})
+	t.Run("malformed column selector should throw error with the column name", func(t *testing.T) {
+		framer := Framer{Columns: []anyframer.Column{{Selector: "name"}, {Selector: "salary)", Alias: "Salary", Format: anyframer.ColumnFormatNumber}}}
+		_, err := framer.ToFrame(`[{"name":"foo","salary": 123}]`)
+		require.NotNil(t, err)
+		require.ErrorContains(t, err, `invalid selector "salary)" for column "Salary".`)
+	})
+}
@@ -0,0 +1,84 @@
+package anyframer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// SchemaField ...
+// Name is the property name after flattening such as owner.login. Type is one of the column formats and defaults to string
+type SchemaField struct {
+	Name string       `json:"name"`
+	Type ColumnFormat `json:"type,omitempty"`
+}
+
+// columns returns the columns used to build the frames. When no columns are defined, the schema fields are used as columns
+// so that the frames always have the schema fields in the same order and type regardless of the values present
+func (framerOptions AnyFramer) columns() ([]Column, error) {
+	if len(framerOptions.Columns) > 0 || len(framerOptions.Schema) == 0 {
+		return framerOptions.Columns, nil
+	}
+	columns := []Column{}
+	for _, field := range framerOptions.Schema {
+		if field.Name == "" {
+			return nil, errors.New("invalid/empty schema field name")
+		}
+		if _, err := schemaFieldType(field); err != nil {
+			return nil, err
+		}
+		format := field.Type
+		if format == "" {
+			format = ColumnFormatString
+		}
+		columns = append(columns, Column{Selector: "`" + strings.ReplaceAll(field.Name, "`", "") + "`", Alias: field.Name, Format: format})
+	}
+	return columns, nil
+}
+
+// sliceToFrame converts the items to frame using the columns or schema. When the schema is defined, empty input still returns the schema fields
+func (framerOptions AnyFramer) sliceToFrame(name string, items []any) (*data.Frame, error) {
+	columns, err := framerOptions.columns()
+	if err != nil {
+		return nil, err
+	}
+	frame, err := SliceToFrame(name, items, columns)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 && len(framerOptions.Columns) == 0 && len(framerOptions.Schema) > 0 {
+		return newSchemaFrame(name, framerOptions.Schema)
+	}
+	return frame, nil
+}
+
+func newSchemaFrame(name string, schema []SchemaField) (*data.Frame, error) {
+	frame := data.NewFrame(name)
+	for _, field := range schema {
+		fieldType, err := schemaFieldType(field)
+		if err != nil {
+			return nil, err
+		}
+		f := data.NewFieldFromFieldType(fieldType, 0)
+		f.Name = field.Name
+		frame.Fields = append(frame.Fields, f)
+	}
+	return frame, nil
+}
+
+func schemaFieldType(field SchemaField) (data.FieldType, error) {
+	switch field.Type {
+	case "", ColumnFormatString:
+		return data.FieldTypeNullableString, nil
+	case ColumnFormatNumber:
+		return data.FieldTypeNullableFloat64, nil
+	case ColumnFormatBoolean:
+		return data.FieldTypeNullableBool, nil
+	case ColumnFormatTimeStamp, ColumnFormatUnixMsecTimeStamp, ColumnFormatUnixSecTimeStamp, ColumnFormatUnixUsecTimeStamp, ColumnFormatUnixNsecTimeStamp:
+		return data.FieldTypeNullableTime, nil
+	default:
+		return data.FieldTypeUnknown, fmt.Errorf("invalid schema type %q for field %q", field.Type, field.Name)
+	}
+}
@@ -0,0 +1,77 @@
+package anyframer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana-plugin-sdk-go/experimental"
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+)
+
+func TestToFrame_Schema(t *testing.T) {
+	schema := []anyframer.SchemaField{
+		{Name: "name"},
+		{Name: "age", Type: anyframer.ColumnFormatNumber},
+		{Name: "owner.login"},
+		{Name: "active", Type: anyframer.ColumnFormatBoolean},
+		{Name: "updated", Type: anyframer.ColumnFormatTimeStamp},
+	}
+	fieldTypes := func(frame *data.Frame) (out []string) {
+		for _, field := range frame.Fields {
+			out = append(out, field.Name+":"+field.Type().ItemTypeString())
+		}
+		return out
+	}
+	wantFieldTypes := []string{"name:*string", "age:*float64", "owner.login:*string", "active:*bool", "updated:*time.Time"}
+	t.Run("responses with different shapes", func(t *testing.T) {
+		frames := data.Frames{}
+		for _, input := range []string{
+			`[{"name":"foo","age":"12","owner":{"login":"bar"},"active":"true","updated":"2023-07-01","extra":1}]`,
+			`[{"age":null,"name":"foo","active":null},{"name":"bar"}]`,
+			`[]`,
+			`{"name":"foo","age":30}`,
+		} {
+			framer := anyframer.AnyFramer{Schema: schema, FlattenOptions: anyframer.FlattenOptions{Enabled: true}}
+			frame, err := framer.ToFrame(input)
+			require.Nil(t, err)
+			require.Equal(t, wantFieldTypes, fieldTypes(frame))
+			frames = append(frames, frame)
+		}
+		experimental.CheckGoldenJSONResponse(t, "testdata/golden", t.Name(), &backend.DataResponse{Frames: frames}, updateGoldenFile)
+	})
+	t.Run("from reader", func(t *testing.T) {
+		for _, input := range []string{`[{"name":"foo","age":12}]`, `[]`} {
+			framer := anyframer.AnyFramer{Schema: schema}
+			frame, err := framer.ToFrameFromReader(strings.NewReader(input))
+			require.Nil(t, err)
+			require.Equal(t, wantFieldTypes, fieldTypes(frame))
+		}
+	})
+	t.Run("group by", func(t *testing.T) {
+		framer := anyframer.AnyFramer{Schema: schema, SplitOptions: anyframer.SplitOptions{Mode: anyframer.SplitModeGroupBy, GroupBy: "name"}}
+		frames, err := framer.ToFrames(`[{"name":"foo","age":12},{"name":"bar","active":true}]`)
+		require.Nil(t, err)
+		require.Equal(t, 2, len(frames))
+		require.Equal(t, wantFieldTypes, fieldTypes(frames[0]))
+		require.Equal(t, wantFieldTypes, fieldTypes(frames[1]))
+	})
+	t.Run("columns take precedence over schema", func(t *testing.T) {
+		framer := anyframer.AnyFramer{Schema: schema, Columns: []anyframer.Column{{Selector: "name", Format: anyframer.ColumnFormatString}}}
+		frame, err := framer.ToFrame(`[{"name":"foo","age":12}]`)
+		require.Nil(t, err)
+		require.Equal(t, []string{"name:*string"}, fieldTypes(frame))
+	})
+	t.Run("invalid schema type should throw error", func(t *testing.T) {
+		framer := anyframer.AnyFramer{Schema: []anyframer.SchemaField{{Name: "age", Type: "foo"}}}
+		_, err := framer.ToFrame(`[{"age":12}]`)
+		require.EqualError(t, err, `invalid schema type "foo" for field "age"`)
+	})
+	t.Run("empty schema field name should throw error", func(t *testing.T) {
+		framer := anyframer.AnyFramer{Schema: []anyframer.SchemaField{{Type: anyframer.ColumnFormatNumber}}}
+		_, err := framer.ToFrame(`[{"age":12}]`)
+		require.EqualError(t, err, "invalid/empty schema field name")
+	})
+}
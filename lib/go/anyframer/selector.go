@@ -1,11 +1,60 @@
 package anyframer
 
 import (
+	"container/list"
 	"errors"
+	"fmt"
+	"sync"
 
 	jsonata "github.com/xiatechs/jsonata-go"
 )
 
+const selectorCacheSize = 512
+
+// selectorCache is a bounded LRU cache of the compiled jsonata expressions shared across the framer calls.
+// Compiled expressions are safe for concurrent evaluation
+type selectorCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type selectorCacheEntry struct {
+	selector string
+	expr     *jsonata.Expr
+}
+
+var selectors = newSelectorCache(selectorCacheSize)
+
+func newSelectorCache(size int) *selectorCache {
+	return &selectorCache{size: size, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+func (c *selectorCache) compile(selector string) (*jsonata.Expr, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[selector]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*selectorCacheEntry).expr, nil
+	}
+	expr, err := jsonata.Compile(selector)
+	if err != nil {
+		return nil, err
+	}
+	c.entries[selector] = c.order.PushFront(&selectorCacheEntry{selector: selector, expr: expr})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*selectorCacheEntry).selector)
+	}
+	return expr, nil
+}
+
+func compileSelector(selector string) (*jsonata.Expr, error) {
+	return selectors.compile(selector)
+}
+
 func applySelector(input any, selector string) (output any, err error) {
 	if input == nil {
 		return nil, errors.New("invalid/empty data")
@@ -13,7 +62,17 @@ func applySelector(input any, selector string) (output any, err error) {
 	if selector == "" {
 		return input, nil
 	}
-	e := jsonata.MustCompile(selector)
+	e, err := compileSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid root selector %q. %w", selector, err)
+	}
+	return evalSelector(e, input)
+}
+
+func evalSelector(e *jsonata.Expr, input any) (output any, err error) {
+	if input == nil {
+		return nil, errors.New("invalid/empty data")
+	}
 	res, err := e.Eval(input)
 	if err != nil {
 		return nil, errors.New("error applying root selector")
@@ -0,0 +1,51 @@
+package anyframer_test
+
+import (
+	"fmt"
+	"testing"
+
+	jsonata "github.com/xiatechs/jsonata-go"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+)
+
+func benchmarkInput(rows int, columns int) ([]any, []anyframer.Column) {
+	input := make([]any, rows)
+	for i := 0; i < rows; i++ {
+		item := map[string]any{}
+		for c := 0; c < columns; c++ {
+			item[fmt.Sprintf("col%d", c)] = map[string]any{"value": float64(i * c)}
+		}
+		input[i] = item
+	}
+	cols := make([]anyframer.Column, columns)
+	for c := 0; c < columns; c++ {
+		cols[c] = anyframer.Column{Selector: fmt.Sprintf("col%d.value", c), Format: anyframer.ColumnFormatNumber}
+	}
+	return input, cols
+}
+
+// BenchmarkSliceToFrame compiles each column selector once per call
+func BenchmarkSliceToFrame(b *testing.B) {
+	input, columns := benchmarkInput(10000, 20)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := anyframer.SliceToFrame("response", input, columns); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSliceToFrame_CompilePerCell is the baseline of compiling the selector for every row and column
+func BenchmarkSliceToFrame_CompilePerCell(b *testing.B) {
+	input, columns := benchmarkInput(10000, 20)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, column := range columns {
+			for _, item := range input {
+				if _, err := jsonata.MustCompile(column.Selector).Eval(item); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+}
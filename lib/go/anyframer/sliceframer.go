@@ -24,12 +24,17 @@ func SliceToFrame(name string, input []any, columns []Column) (frame *data.Frame
 			if fieldName == "" {
 				fieldName = column.Selector
 			}
+			expr, err := compileSelector(column.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector %q for column %q. %w", column.Selector, fieldName, err)
+			}
+			fieldsCount := len(frame.Fields)
 			switch column.Format {
 			case ColumnFormatString:
 				field := data.NewFieldFromFieldType(data.FieldTypeNullableString, len(input))
 				field.Name = fieldName
 				for i := 0; i < len(input); i++ {
-					currentValue, err := applySelector(input[i], column.Selector)
+					currentValue, err := evalSelector(expr, input[i])
 					if err != nil {
 						continue
 					}
@@ -52,7 +57,7 @@ func SliceToFrame(name string, input []any, columns []Column) (frame *data.Frame
 				field := data.NewFieldFromFieldType(data.FieldTypeNullableFloat64, len(input))
 				field.Name = fieldName
 				for i := 0; i < len(input); i++ {
-					currentValue, err := applySelector(input[i], column.Selector)
+					currentValue, err := evalSelector(expr, input[i])
 					if err != nil {
 						continue
 					}
@@ -73,7 +78,7 @@ func SliceToFrame(name string, input []any, columns []Column) (frame *data.Frame
 				field := data.NewFieldFromFieldType(data.FieldTypeNullableBool, len(input))
 				field.Name = fieldName
 				for i := 0; i < len(input); i++ {
-					currentValue, err := applySelector(input[i], column.Selector)
+					currentValue, err := evalSelector(expr, input[i])
 					if err != nil {
 						continue
 					}
@@ -97,22 +102,41 @@ func SliceToFrame(name string, input []any, columns []Column) (frame *data.Frame
 			case ColumnFormatTimeStamp:
 				field := data.NewFieldFromFieldType(data.FieldTypeNullableTime, len(input))
 				field.Name = fieldName
+				loc, err := getLocation(column.Timezone)
+				if err != nil {
+					return nil, fmt.Errorf("invalid timezone %q for column %q. %w", column.Timezone, fieldName, err)
+				}
 				for i := 0; i < len(input); i++ {
-					currentValue, err := applySelector(input[i], column.Selector)
+					currentValue, err := evalSelector(expr, input[i])
 					if err != nil {
 						continue
 					}
 					switch a := currentValue.(type) {
 					case float64:
+						if column.TimeFormat != "" && column.TimeFormat != "auto" {
+							v := strconv.FormatFloat(a, 'f', -1, 64)
+							t, err := getTimeFromFormat(v, column.TimeFormat, loc)
+							if err != nil {
+								return nil, fmt.Errorf("invalid time value %q for column %q. %w", v, fieldName, err)
+							}
+							field.Set(i, t)
+							continue
+						}
 						if v := fmt.Sprintf("%v", currentValue); v != "" {
-							if t, err := time.Parse("2006", v); err == nil {
+							if t, err := time.ParseInLocation("2006", v, loc); err == nil {
 								field.Set(i, ToPointer(t))
 							}
 						}
 					case string:
 						if currentValue.(string) != "" {
-							field.Set(i, getTimeFromString(currentValue.(string), column.TimeFormat))
+							t, err := getTimeFromFormat(currentValue.(string), column.TimeFormat, loc)
+							if err != nil {
+								return nil, fmt.Errorf("invalid time value %q for column %q. %w", currentValue.(string), fieldName, err)
+							}
+							field.Set(i, t)
 						}
+					case time.Time:
+						field.Set(i, ToPointer(a))
 					default:
 						noop(a)
 						field.Set(i, nil)
@@ -123,7 +147,7 @@ func SliceToFrame(name string, input []any, columns []Column) (frame *data.Frame
 				field := data.NewFieldFromFieldType(data.FieldTypeNullableTime, len(input))
 				field.Name = fieldName
 				for i := 0; i < len(input); i++ {
-					currentValue, err := applySelector(input[i], column.Selector)
+					currentValue, err := evalSelector(expr, input[i])
 					if err != nil {
 						continue
 					}
@@ -144,7 +168,7 @@ func SliceToFrame(name string, input []any, columns []Column) (frame *data.Frame
 				field := data.NewFieldFromFieldType(data.FieldTypeNullableTime, len(input))
 				field.Name = fieldName
 				for i := 0; i < len(input); i++ {
-					currentValue, err := applySelector(input[i], column.Selector)
+					currentValue, err := evalSelector(expr, input[i])
 					if err != nil {
 						continue
 					}
@@ -161,6 +185,30 @@ func SliceToFrame(name string, input []any, columns []Column) (frame *data.Frame
 					}
 				}
 				frame.Fields = append(frame.Fields, field)
+			case ColumnFormatUnixUsecTimeStamp, ColumnFormatUnixNsecTimeStamp:
+				field := data.NewFieldFromFieldType(data.FieldTypeNullableTime, len(input))
+				field.Name = fieldName
+				for i := 0; i < len(input); i++ {
+					currentValue, err := evalSelector(expr, input[i])
+					if err != nil {
+						continue
+					}
+					switch cvt := currentValue.(type) {
+					case string:
+						if item, err := strconv.ParseInt(currentValue.(string), 10, 64); err == nil && currentValue.(string) != "" {
+							field.Set(i, ToPointer(getTimeFromEpoch(item, column.Format)))
+						}
+					case float64:
+						field.Set(i, ToPointer(getTimeFromEpoch(int64(currentValue.(float64)), column.Format)))
+					default:
+						noop(cvt)
+						field.Set(i, nil)
+					}
+				}
+				frame.Fields = append(frame.Fields, field)
+			}
+			if len(frame.Fields) > fieldsCount {
+				frame.Fields[fieldsCount].Config = column.fieldConfig()
 			}
 		}
 		return frame, nil
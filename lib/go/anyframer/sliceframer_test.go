@@ -1,7 +1,9 @@
 package anyframer_test
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/experimental"
 	"github.com/stretchr/testify/assert"
@@ -81,3 +83,68 @@ func TestSliceToFrame(t *testing.T) {
 		})
 	}
 }
+
+func TestSliceToFrame_Timestamps(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	require.Nil(t, err)
+	tests := []struct {
+		name    string
+		value   any
+		column  af.Column
+		want    time.Time
+		wantErr string
+	}{
+		{name: "auto format", value: "2023-07-01 10:30", column: af.Column{Format: af.ColumnFormatTimeStamp}, want: time.Date(2023, 7, 1, 10, 30, 0, 0, time.UTC)},
+		{name: "auto format with timezone", value: "2023-07-01 10:30", column: af.Column{Format: af.ColumnFormatTimeStamp, Timezone: "Europe/London"}, want: time.Date(2023, 7, 1, 10, 30, 0, 0, london)},
+		{name: "auto format with zone information ignores timezone", value: "2023-07-01T10:30:00Z", column: af.Column{Format: af.ColumnFormatTimeStamp, Timezone: "Europe/London"}, want: time.Date(2023, 7, 1, 10, 30, 0, 0, time.UTC)},
+		{name: "moment format", value: "01/07/2023 10:30", column: af.Column{Format: af.ColumnFormatTimeStamp, TimeFormat: "DD/MM/YYYY HH:mm"}, want: time.Date(2023, 7, 1, 10, 30, 0, 0, time.UTC)},
+		{name: "strftime format with timezone", value: "01/Jul/2023:10:30:00", column: af.Column{Format: af.ColumnFormatTimeStamp, TimeFormat: "%d/%b/%Y:%H:%M:%S", Timezone: "Europe/London"}, want: time.Date(2023, 7, 1, 10, 30, 0, 0, london)},
+		{name: "go layout", value: "Jul 1, 2023 at 10:30am (BST)", column: af.Column{Format: af.ColumnFormatTimeStamp, TimeFormat: "Jan 2, 2006 at 3:04pm (MST)", Timezone: "Europe/London"}, want: time.Date(2023, 7, 1, 10, 30, 0, 0, london)},
+		{name: "numeric value with format", value: float64(20230701), column: af.Column{Format: af.ColumnFormatTimeStamp, TimeFormat: "%Y%m%d"}, want: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "epoch micro seconds", value: float64(1688207400000000), column: af.Column{Format: af.ColumnFormatUnixUsecTimeStamp}, want: time.Date(2023, 7, 1, 10, 30, 0, 0, time.UTC)},
+		{name: "epoch nano seconds", value: "1688207400123456789", column: af.Column{Format: af.ColumnFormatUnixNsecTimeStamp}, want: time.Date(2023, 7, 1, 10, 30, 0, 123456789, time.UTC)},
+		{name: "value not matching the format should throw error", value: "2023-07-01", column: af.Column{Format: af.ColumnFormatTimeStamp, TimeFormat: "DD/MM/YYYY", Alias: "joined"}, wantErr: `invalid time value "2023-07-01" for column "joined". expected format "DD/MM/YYYY"`},
+		{name: "invalid timezone should throw error", value: "2023-07-01", column: af.Column{Format: af.ColumnFormatTimeStamp, Timezone: "Foo/Bar"}, wantErr: `invalid timezone "Foo/Bar" for column "value". unknown time zone Foo/Bar`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.column.Selector = "value"
+			frame, err := af.SliceToFrame("response", []any{map[string]any{"value": tt.value}}, []af.Column{tt.column})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			got, ok := frame.Fields[0].ConcreteAt(0)
+			require.True(t, ok)
+			assert.True(t, tt.want.Equal(got.(time.Time)), "want %v, got %v", tt.want, got)
+		})
+	}
+}
+
+func TestSliceToFrame_FieldConfig(t *testing.T) {
+	columns := []af.Column{}
+	err := json.Unmarshal([]byte(`[
+		{ "selector": "uuid", "format": "string", "links": [{ "title": "Open monitor", "url": "https://app.hyperping.io/report/${__data.fields.uuid}", "targetBlank": true }] },
+		{ "selector": "status", "format": "string", "displayName": "Status", "mappings": [{ "type": "value", "options": { "up": { "text": "Up", "color": "green" } } }] },
+		{ "selector": "uptime", "format": "number", "unit": "percent", "decimals": 2, "min": 0, "max": 100 }
+	]`), &columns)
+	require.Nil(t, err)
+	input := []any{
+		map[string]any{"uuid": "mon_1", "status": "up", "uptime": 99.987},
+		map[string]any{"uuid": "mon_2", "status": "down", "uptime": 12.5},
+	}
+	t.Run("slice", func(t *testing.T) {
+		frame, err := af.SliceToFrame("response", input, columns)
+		require.Nil(t, err)
+		experimental.CheckGoldenJSONFrame(t, "testdata/golden", t.Name(), frame, updateGoldenFile)
+	})
+	t.Run("struct", func(t *testing.T) {
+		frame, err := af.StructToFrame("response", input[0], columns...)
+		require.Nil(t, err)
+		require.Equal(t, []string{"status", "uptime", "uuid"}, []string{frame.Fields[0].Name, frame.Fields[1].Name, frame.Fields[2].Name})
+		require.Equal(t, "Status", frame.Fields[0].Config.DisplayNameFromDS)
+		require.Equal(t, "percent", frame.Fields[1].Config.Unit)
+		require.Equal(t, "https://app.hyperping.io/report/${__data.fields.uuid}", frame.Fields[2].Config.Links[0].URL)
+	})
+}
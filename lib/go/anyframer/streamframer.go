@@ -0,0 +1,431 @@
+package anyframer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+const streamChunkSize = 1000
+
+var simplePathSelector = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// ToFrameFromReader converts the json array read from the reader into data frame without reading the whole input in to memory.
+// The array can be at the root or at the simple path root selector such as data.items. Rows are decoded token by token and appended to the fields.
+// Output is identical to ToFrame. Other input types and complex root selectors are read fully and converted using ToFrame
+func (framerOptions *AnyFramer) ToFrameFromReader(input io.Reader) (*data.Frame, error) {
+	if framerOptions.Name == "" {
+		framerOptions.Name = "response"
+	}
+	if input == nil {
+		return nil, errors.New("invalid/empty input")
+	}
+	reader := bufio.NewReader(input)
+	first, err := peekNonSpace(reader)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	inputType := framerOptions.GuessType("")
+	canStream := (inputType == InputTypeJSON && (first == '[' || first == '{')) || (inputType == InputTypeUnknown && first == '[')
+	if !canStream || !isSimplePathSelector(framerOptions.RootSelector) {
+		b, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		return framerOptions.ToFrame(b)
+	}
+	framerOptions.InputType = InputTypeJSON
+	return toFrameFromJSONStream(json.NewDecoder(reader), *framerOptions)
+}
+
+func peekNonSpace(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+			return b, reader.UnreadByte()
+		}
+	}
+}
+
+func isSimplePathSelector(selector string) bool {
+	if selector == "" {
+		return true
+	}
+	if !simplePathSelector.MatchString(selector) {
+		return false
+	}
+	for _, key := range strings.Split(selector, ".") {
+		switch key {
+		case "and", "or", "in", "true", "false", "null", "function":
+			return false
+		}
+	}
+	return true
+}
+
+// toFrameFromJSONStream seeks the root selector path and streams the array found. When the path is not an object chain,
+// the remaining value is decoded and framed the same way as ToFrame
+func toFrameFromJSONStream(dec *json.Decoder, options AnyFramer) (*data.Frame, error) {
+	path := []string{}
+	if options.RootSelector != "" {
+		path = strings.Split(options.RootSelector, ".")
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	depth := 0
+	for depth < len(path) {
+		if tok != json.Delim('{') {
+			value, err := decodeTokenValue(dec, tok)
+			if err != nil {
+				return nil, err
+			}
+			output, err := applySelector(value, strings.Join(path[depth:], "."))
+			if err != nil {
+				return nil, err
+			}
+			options.RootSelector = ""
+			return toFrameFromInterface(output, options)
+		}
+		found := false
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			if key == path[depth] {
+				found = true
+				break
+			}
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, err
+			}
+		}
+		if !found {
+			return nil, errors.New("error applying root selector")
+		}
+		if tok, err = dec.Token(); err != nil {
+			return nil, err
+		}
+		depth++
+	}
+	var frame *data.Frame
+	if tok == json.Delim('[') {
+		if frame, err = streamJSONArray(dec, options); err != nil {
+			return nil, err
+		}
+	} else {
+		value, err := decodeTokenValue(dec, tok)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil && len(path) > 0 {
+			return nil, errors.New("error applying root selector")
+		}
+		options.RootSelector = ""
+		if frame, err = toFrameFromInterface(value, options); err != nil {
+			return nil, err
+		}
+	}
+	if err := skipJSONStream(dec, depth); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// decodeTokenValue returns the value of the token. For objects and arrays, the remaining items are decoded
+func decodeTokenValue(dec *json.Decoder, tok json.Token) (any, error) {
+	switch tok {
+	case json.Delim('{'):
+		out := map[string]any{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			var value any
+			if err := dec.Decode(&value); err != nil {
+				return nil, err
+			}
+			out[fmt.Sprintf("%v", key)] = value
+		}
+		_, err := dec.Token()
+		return out, err
+	case json.Delim('['):
+		out := []any{}
+		for dec.More() {
+			var value any
+			if err := dec.Decode(&value); err != nil {
+				return nil, err
+			}
+			out = append(out, value)
+		}
+		_, err := dec.Token()
+		return out, err
+	default:
+		return tok, nil
+	}
+}
+
+// skipJSONStream reads the remaining tokens of the enclosing objects and ensures no more data exists after the top-level value
+func skipJSONStream(dec *json.Decoder, depth int) error {
+	for depth > 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("invalid json. unexpected data after top-level value")
+	}
+	return nil
+}
+
+// streamJSONArray decodes the array items in chunks. Explode and flatten options are applied per chunk as they work on individual items
+func streamJSONArray(dec *json.Decoder, options AnyFramer) (*data.Frame, error) {
+	builder := newFrameBuilder(options.Name)
+	var frame *data.Frame
+	chunk := make([]any, 0, streamChunkSize)
+	explodedRows, flattenedRows := 0, 0
+	flush := func() error {
+		items := chunk
+		chunk = chunk[:0]
+		if len(options.Explode.Selectors) > 0 {
+			var err error
+			if items, err = explodeSlice(items, options.Explode); err != nil {
+				return err
+			}
+			// explode is applied per chunk, so the rows are limited across the chunks
+			if explodedRows += len(items); explodedRows > options.Explode.maxRows() {
+				return errExplodeMaxRows(options.Explode.maxRows())
+			}
+		}
+		if len(options.Columns) == 0 && options.FlattenOptions.Enabled {
+			var err error
+			if items, err = flattenSlice(items, options.FlattenOptions); err != nil {
+				return err
+			}
+			// flatten is applied per chunk, so the rows are limited across the chunks
+			if flattenedRows += len(items); flattenedRows > options.FlattenOptions.maxRows() {
+				return errFlattenMaxRows(options.FlattenOptions.maxRows())
+			}
+		}
+		if len(options.Columns) == 0 && len(options.Schema) == 0 {
+			for _, item := range items {
+				builder.append(item)
+			}
+			return nil
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		chunkFrame, err := options.sliceToFrame(options.Name, items)
+		if err != nil {
+			return err
+		}
+		if frame == nil {
+			frame = chunkFrame
+			return nil
+		}
+		for i, field := range chunkFrame.Fields {
+			for j := 0; j < field.Len(); j++ {
+				frame.Fields[i].Append(field.At(j))
+			}
+		}
+		return nil
+	}
+	for dec.More() {
+		var item any
+		if err := dec.Decode(&item); err != nil {
+			return nil, err
+		}
+		if chunk = append(chunk, item); len(chunk) == streamChunkSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if len(options.Columns) == 0 && len(options.Schema) == 0 {
+		return builder.frame(), nil
+	}
+	if frame == nil {
+		return options.sliceToFrame(options.Name, []any{})
+	}
+	return frame, nil
+}
+
+type frameBuilderKind int
+
+const (
+	frameBuilderKindUnknown frameBuilderKind = iota
+	frameBuilderKindScalar
+	frameBuilderKindArray
+	frameBuilderKindObject
+)
+
+// frameBuilder appends the items to the typed fields one by one. Field types are decided the same way SliceToFrame does without columns
+type frameBuilder struct {
+	name   string
+	rows   int
+	kind   frameBuilderKind
+	field  *data.Field
+	fields map[string]*frameBuilderField
+}
+
+type frameBuilderField struct {
+	field  *data.Field
+	isJSON bool
+}
+
+func newFrameBuilder(name string) *frameBuilder {
+	return &frameBuilder{name: name, fields: map[string]*frameBuilderField{}}
+}
+
+func (b *frameBuilder) append(item any) {
+	if b.kind == frameBuilderKindUnknown && item != nil {
+		switch item.(type) {
+		case []any:
+			b.kind = frameBuilderKindArray
+			b.field = data.NewFieldFromFieldType(data.FieldTypeNullableString, 0)
+			for i := 0; i < b.rows; i++ {
+				b.field.Append(ToPointer("null"))
+			}
+		case map[string]any:
+			b.kind = frameBuilderKindObject
+		default:
+			fieldType, _ := getFieldTypeAndValue(item)
+			b.kind = frameBuilderKindScalar
+			b.field = data.NewFieldFromFieldType(fieldType, b.rows)
+		}
+		if b.field != nil {
+			b.field.Name = b.name
+		}
+	}
+	switch b.kind {
+	case frameBuilderKindScalar:
+		b.field.Append(toFieldValue(b.field.Type(), item))
+	case frameBuilderKindArray:
+		b.field.Append(toJSONStringPointer(item))
+	case frameBuilderKindObject:
+		if o, ok := item.(map[string]any); ok {
+			for k, v := range o {
+				b.set(k, v)
+			}
+		}
+		for _, f := range b.fields {
+			if f.field != nil && f.field.Len() == b.rows {
+				f.field.Append(f.nullValue())
+			}
+		}
+	}
+	b.rows++
+}
+
+// set appends the value of the key to the current row. Fields are created on the first non null value and back filled with nulls
+func (b *frameBuilder) set(key string, value any) {
+	f, ok := b.fields[key]
+	if !ok {
+		f = &frameBuilderField{}
+		b.fields[key] = f
+	}
+	if f.field == nil {
+		if value == nil {
+			return
+		}
+		fieldType, _ := getFieldTypeAndValue(value)
+		f.isJSON = fieldType == data.FieldTypeJSON
+		if f.isJSON {
+			fieldType = data.FieldTypeNullableString
+		}
+		f.field = data.NewFieldFromFieldType(fieldType, 0)
+		f.field.Name = key
+		for i := 0; i < b.rows; i++ {
+			f.field.Append(f.nullValue())
+		}
+	}
+	if f.isJSON {
+		f.field.Append(toJSONStringPointer(value))
+		return
+	}
+	f.field.Append(toFieldValue(f.field.Type(), value))
+}
+
+func (f *frameBuilderField) nullValue() any {
+	if f.isJSON {
+		return ToPointer("null")
+	}
+	return nil
+}
+
+func (b *frameBuilder) frame() *data.Frame {
+	frame := data.NewFrame(b.name)
+	if b.rows == 0 {
+		return frame
+	}
+	if b.field != nil {
+		frame.Fields = append(frame.Fields, b.field)
+	}
+	keys := make([]string, 0, len(b.fields))
+	for k := range b.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		f := b.fields[k]
+		if f.field == nil {
+			f.field = data.NewFieldFromFieldType(data.FieldTypeNullableString, b.rows)
+			f.field.Name = k
+		}
+		frame.Fields = append(frame.Fields, f.field)
+	}
+	if len(frame.Fields) == 0 {
+		field := data.NewFieldFromFieldType(data.FieldTypeNullableString, b.rows)
+		field.Name = b.name
+		frame.Fields = append(frame.Fields, field)
+	}
+	return frame
+}
+
+// toFieldValue returns the pointer value for the field type. Values of a different type are returned as null
+func toFieldValue(fieldType data.FieldType, value any) any {
+	if value == nil {
+		return nil
+	}
+	valueType, v := getFieldTypeAndValue(value)
+	if valueType != fieldType {
+		return nil
+	}
+	return ToPointer(v)
+}
+
+func toJSONStringPointer(value any) any {
+	o, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return ToPointer(string(o))
+}
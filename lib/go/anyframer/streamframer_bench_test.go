@@ -0,0 +1,36 @@
+package anyframer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+)
+
+// BenchmarkToFrame_LargeArray is the baseline of unmarshalling the whole input before building the frame
+func BenchmarkToFrame_LargeArray(b *testing.B) {
+	input := testLargeJSONArray(100000)
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		framer := anyframer.AnyFramer{}
+		if _, err := framer.ToFrame(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkToFrameFromReader_LargeArray decodes the rows token by token into the fields
+func BenchmarkToFrameFromReader_LargeArray(b *testing.B) {
+	input := testLargeJSONArray(100000)
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		framer := anyframer.AnyFramer{}
+		if _, err := framer.ToFrameFromReader(strings.NewReader(input)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package anyframer_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+)
+
+func testLargeJSONArray(rows int) string {
+	items := []string{}
+	for i := 0; i < rows; i++ {
+		switch {
+		case i%7 == 0:
+			items = append(items, fmt.Sprintf(`{"id":%d,"name":"user %d","active":true,"score":null}`, i, i))
+		case i > 1500:
+			items = append(items, fmt.Sprintf(`{"id":%d,"name":"user %d","score":%d.5,"tags":["a","b"],"meta":{"region":"emea"}}`, i, i, i))
+		default:
+			items = append(items, fmt.Sprintf(`{"id":%d,"name":"user %d","active":false,"score":%d}`, i, i, i))
+		}
+	}
+	return "[" + strings.Join(items, ",") + "]"
+}
+
+func TestToFrameFromReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		framer  anyframer.AnyFramer
+		wantErr string
+	}{
+		{name: "array of objects", input: `[{"name":"foo","age":12,"tags":["a"]},{"name":"bar","city":"chennai","meta":{"a":1}},{"age":null}]`},
+		{name: "array of objects with late keys and nulls", input: `[{"a":null},{"b":1},{"a":"x","c":{"d":1}},null,"foo",{"c":null}]`},
+		{name: "array of numbers", input: `[1, null, 2.5]`},
+		{name: "array of arrays", input: `[null, [1,2], ["a"]]`},
+		{name: "array of nulls", input: `[null, null]`},
+		{name: "array of empty objects", input: `[{}, {}]`},
+		{name: "empty array", input: ` [] `},
+		{name: "object", input: `{"name":"foo","age":12}`, framer: anyframer.AnyFramer{InputType: anyframer.InputTypeJSON}},
+		{name: "root selector", input: `{"meta":{"count":2},"data":{"items":[{"name":"foo"},{"name":"bar"}],"next":"abc"}}`, framer: anyframer.AnyFramer{RootSelector: "data.items", InputType: anyframer.InputTypeJSON}},
+		{name: "root selector with array in the path", input: `{"data":[{"items":[{"name":"foo"}]},{"items":[{"name":"bar"}]}]}`, framer: anyframer.AnyFramer{RootSelector: "data.items", RawURL: "https://foo.com/users.json"}},
+		{name: "root selector with object value", input: `{"data":{"user":{"name":"foo"}}}`, framer: anyframer.AnyFramer{RootSelector: "data.user", InputType: anyframer.InputTypeJSON}},
+		{name: "complex root selector", input: `[{"name":"foo","age":12},{"name":"bar","age":30}]`, framer: anyframer.AnyFramer{RootSelector: "$[age > 20]"}},
+		{name: "columns", input: testLargeJSONArray(2500), framer: anyframer.AnyFramer{Columns: []anyframer.Column{
+			{Selector: "id", Format: anyframer.ColumnFormatNumber},
+			{Selector: "name", Alias: "Name", Format: anyframer.ColumnFormatString, Unit: "short"},
+			{Selector: "active", Format: anyframer.ColumnFormatBoolean},
+		}}},
+		{name: "large array", input: testLargeJSONArray(2500)},
+		{name: "large array with flatten", input: testLargeJSONArray(2500), framer: anyframer.AnyFramer{FlattenOptions: anyframer.FlattenOptions{Enabled: true}}},
+		{name: "explode", input: `[{"name":"foo","tags":["a","b"]},{"name":"bar","tags":[]}]`, framer: anyframer.AnyFramer{Explode: anyframer.ExplodeOptions{Selectors: []string{"tags"}}}},
+		{name: "csv", input: "name,age\nfoo,12\nbar,30"},
+		{name: "missing root selector should throw error", input: `{"data":{}}`, framer: anyframer.AnyFramer{RootSelector: "data.items", InputType: anyframer.InputTypeJSON}, wantErr: "error applying root selector"},
+		{name: "null root selector should throw error", input: `{"data":{"items":null}}`, framer: anyframer.AnyFramer{RootSelector: "data.items", InputType: anyframer.InputTypeJSON}, wantErr: "error applying root selector"},
+		{name: "data after the array should throw error", input: `[1,2] [3]`, framer: anyframer.AnyFramer{InputType: anyframer.InputTypeJSON}, wantErr: "invalid json. unexpected data after top-level value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streamFramer := tt.framer
+			gotFrame, err := streamFramer.ToFrameFromReader(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			framer := tt.framer
+			wantFrame, err := framer.ToFrame(tt.input)
+			require.Nil(t, err)
+			requireEqualFrames(t, wantFrame, gotFrame)
+		})
+	}
+}
+
+func requireEqualFrames(t *testing.T, want *data.Frame, got *data.Frame) {
+	t.Helper()
+	wantJSON, err := json.Marshal(want)
+	require.Nil(t, err)
+	gotJSON, err := json.Marshal(got)
+	require.Nil(t, err)
+	require.JSONEq(t, string(wantJSON), string(gotJSON))
+}
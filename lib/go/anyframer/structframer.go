@@ -9,7 +9,8 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 )
 
-func StructToFrame(name string, input any) (frame *data.Frame, err error) {
+// StructToFrame converts the object into a single row frame. Field config of the columns are applied to the matching properties
+func StructToFrame(name string, input any, columns ...Column) (frame *data.Frame, err error) {
 	frame = data.NewFrame(name)
 	if in, ok := input.(map[string]any); ok {
 		fields := map[string]*data.Field{}
@@ -47,6 +48,7 @@ func StructToFrame(name string, input any) (frame *data.Frame, err error) {
 				frame.Fields = append(frame.Fields, f)
 			}
 		}
+		applyFieldConfig(frame, columns)
 		return frame, err
 	}
 	err = errors.New("unable to construct frame")
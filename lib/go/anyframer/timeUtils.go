@@ -4,17 +4,17 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/yesoreyeram/grafana-plugins/lib/go/macros"
 )
 
 var possibleDateFormats = []string{"2006-01-02", "2006-1-2", "2006/01/02", "2006/1/2", "01/02/2006", "1/2/2006"}
 var possibleDateTimeSeparators = []string{"T", " "}
 var possibleTimeFormats = []string{"", "15:04", "15:04:05.999999", "15:04:05.999999Z", "15:04:05.999999 -07:00", "15:04:05 MST"}
 
-func getTimeFromString(input string, timeFormat string) *time.Time {
-	if timeFormat == "auto" {
-		timeFormat = ""
-	}
-	var possibleLayouts = []string{time.RFC3339, "2006", timeFormat}
+// getTimeFromString parses the input using the known layouts. Values without zone information are parsed in the given location
+func getTimeFromString(input string, loc *time.Location) *time.Time {
+	var possibleLayouts = []string{time.RFC3339, "2006"}
 	for _, d := range possibleDateFormats {
 		for _, t := range possibleTimeFormats {
 			for _, s := range possibleDateTimeSeparators {
@@ -27,9 +27,43 @@ func getTimeFromString(input string, timeFormat string) *time.Time {
 	}
 	possibleLayouts = append(possibleLayouts, "2006-01", "2006/01", "01-2006", "01/2006")
 	for _, layout := range possibleLayouts {
-		if t, err := time.Parse(layout, input); err == nil && layout != "" {
+		if t, err := time.ParseInLocation(layout, input, loc); err == nil {
 			return &t
 		}
 	}
 	return nil
 }
+
+// getTimeFromFormat parses the input strictly with the declared time format. Moment style (YYYY-MM-DD), strftime style (%Y-%m-%d)
+// and go layouts are supported. When no format is declared or the format is auto, the known layouts are tried instead
+func getTimeFromFormat(input string, timeFormat string, loc *time.Location) (*time.Time, error) {
+	if timeFormat == "" || timeFormat == "auto" {
+		return getTimeFromString(input, loc), nil
+	}
+	layouts := []string{macros.ToGoTimeLayout(timeFormat)}
+	if layouts[0] != timeFormat {
+		layouts = append(layouts, timeFormat)
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, input, loc); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("expected format %q", timeFormat)
+}
+
+// getLocation returns the location of the given timezone name such as Europe/London. Empty timezone defaults to UTC
+func getLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(timezone)
+}
+
+// getTimeFromEpoch returns the time of the micro / nano second epoch. Large epochs from json numbers can lose precision, use string values instead
+func getTimeFromEpoch(epoch int64, format ColumnFormat) time.Time {
+	if format == ColumnFormatUnixNsecTimeStamp {
+		return time.Unix(0, epoch)
+	}
+	return time.UnixMicro(epoch)
+}
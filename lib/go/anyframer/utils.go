@@ -1,6 +1,7 @@
 package anyframer
 
 import (
+	"fmt"
 	"sort"
 	"time"
 )
@@ -101,3 +102,43 @@ func ToPointer(value any) any {
 }
 
 func noop(x any) {}
+
+// normalizeObject converts the decoded yaml / toml values into the same shape json.Unmarshal produces.
+// Map keys become strings, numbers become float64 and dates become RFC3339 strings
+func normalizeObject(input any) any {
+	switch x := input.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(x))
+		for k, v := range x {
+			out[k] = normalizeObject(v)
+		}
+		return out
+	case map[any]any:
+		out := make(map[string]any, len(x))
+		for k, v := range x {
+			out[fmt.Sprintf("%v", k)] = normalizeObject(v)
+		}
+		return out
+	case []any:
+		out := make([]any, len(x))
+		for i, v := range x {
+			out[i] = normalizeObject(v)
+		}
+		return out
+	case []map[string]any:
+		out := make([]any, len(x))
+		for i, v := range x {
+			out[i] = normalizeObject(v)
+		}
+		return out
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32:
+		_, v := getFieldTypeAndValue(x)
+		return v
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return x.String()
+	default:
+		return input
+	}
+}
@@ -0,0 +1,209 @@
+package anyframer
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxDateFormatIDs are the built-in number formats representing date / time
+var xlsxDateFormatIDs = map[int]bool{
+	14: true, 15: true, 16: true, 17: true, 18: true, 19: true, 20: true, 21: true, 22: true,
+	27: true, 28: true, 29: true, 30: true, 31: true, 32: true, 33: true, 34: true, 35: true, 36: true,
+	45: true, 46: true, 47: true,
+	50: true, 51: true, 52: true, 53: true, 54: true, 55: true, 56: true, 57: true, 58: true,
+}
+
+var xlsxFormatLiteralRegex = regexp.MustCompile(`"[^"]*"|\\.|\[[^\]]*\]`)
+
+type xlsxSheet struct {
+	file     *excelize.File
+	name     string
+	date1904 bool
+	isDate   map[int]bool
+}
+
+func toObjectFromXLSXString(xlsxString string, options AnyFramer) (jsonObject any, err error) {
+	f, err := excelize.OpenReader(strings.NewReader(xlsxString))
+	if err != nil {
+		return nil, fmt.Errorf("error reading xlsx. %w", err)
+	}
+	defer f.Close()
+	sheet, err := getXLSXSheet(f, options.XLSXOptions)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := f.GetRows(sheet.name)
+	if err != nil {
+		return nil, fmt.Errorf("error reading xlsx sheet %q. %w", sheet.name, err)
+	}
+	startCol, startRow, endCol, endRow, err := getXLSXRange(options.XLSXOptions.Range, rows)
+	if err != nil {
+		return nil, err
+	}
+	records := [][]any{}
+	for r := startRow; r <= endRow && r <= len(rows); r++ {
+		record := []any{}
+		for c := startCol; c <= endCol; c++ {
+			value, err := sheet.getValue(rows[r-1], c, r)
+			if err != nil {
+				return nil, err
+			}
+			record = append(record, value)
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("invalid/empty xlsx")
+	}
+	header := []string{}
+	if options.XLSXOptions.NoHeaders {
+		for i := range records[0] {
+			header = append(header, fmt.Sprintf("%d", i+1))
+		}
+	}
+	if !options.XLSXOptions.NoHeaders {
+		for idx, h := range records[0] {
+			hItem := fmt.Sprintf("%v", h)
+			if h == nil || hItem == "" {
+				hItem = fmt.Sprintf("%d", idx+1)
+			}
+			header = append(header, hItem)
+		}
+		records = records[1:]
+	}
+	out := []any{}
+	for _, row := range records {
+		item := map[string]any{}
+		for hID, h := range header {
+			if hID < len(row) {
+				item[h] = row[hID]
+			}
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+func getXLSXSheet(f *excelize.File, options XLSXOptions) (*xlsxSheet, error) {
+	sheets := f.GetSheetList()
+	name := ""
+	if options.Sheet != "" {
+		for _, s := range sheets {
+			if strings.EqualFold(s, options.Sheet) {
+				name = s
+				break
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("invalid xlsx sheet %q", options.Sheet)
+		}
+	}
+	if options.Sheet == "" {
+		if options.SheetIndex < 0 || options.SheetIndex >= len(sheets) {
+			return nil, fmt.Errorf("invalid xlsx sheet index %d. %d sheet(s) found", options.SheetIndex, len(sheets))
+		}
+		name = sheets[options.SheetIndex]
+	}
+	sheet := &xlsxSheet{file: f, name: name, isDate: map[int]bool{}}
+	if props, err := f.GetWorkbookProps(); err == nil && props.Date1904 != nil {
+		sheet.date1904 = *props.Date1904
+	}
+	return sheet, nil
+}
+
+// getXLSXRange returns the 1 based boundaries of the range. When the range is empty, the used area of the sheet is returned
+func getXLSXRange(rangeRef string, rows [][]string) (startCol, startRow, endCol, endRow int, err error) {
+	if strings.TrimSpace(rangeRef) == "" {
+		for _, row := range rows {
+			if len(row) > endCol {
+				endCol = len(row)
+			}
+		}
+		return 1, 1, endCol, len(rows), nil
+	}
+	cells := strings.Split(strings.ReplaceAll(strings.TrimSpace(rangeRef), "$", ""), ":")
+	if len(cells) != 2 {
+		return 0, 0, 0, 0, fmt.Errorf("invalid xlsx range %q", rangeRef)
+	}
+	if startCol, startRow, err = excelize.CellNameToCoordinates(cells[0]); err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("invalid xlsx range %q. %w", rangeRef, err)
+	}
+	if endCol, endRow, err = excelize.CellNameToCoordinates(cells[1]); err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("invalid xlsx range %q. %w", rangeRef, err)
+	}
+	if startCol > endCol {
+		startCol, endCol = endCol, startCol
+	}
+	if startRow > endRow {
+		startRow, endRow = endRow, startRow
+	}
+	return startCol, startRow, endCol, endRow, nil
+}
+
+// getValue returns the formatted value of the cell. Date formatted cells are converted to time.Time
+func (sheet *xlsxSheet) getValue(row []string, col int, rowIdx int) (any, error) {
+	if col > len(row) {
+		return nil, nil
+	}
+	value := row[col-1]
+	if value == "" {
+		return value, nil
+	}
+	cell, err := excelize.CoordinatesToCellName(col, rowIdx)
+	if err != nil {
+		return nil, err
+	}
+	styleID, err := sheet.file.GetCellStyle(sheet.name, cell)
+	if err != nil || !sheet.isDateStyle(styleID) {
+		return value, nil
+	}
+	raw, err := sheet.file.GetCellValue(sheet.name, cell, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return value, nil
+	}
+	serial, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return value, nil
+	}
+	t, err := excelize.ExcelDateToTime(serial, sheet.date1904)
+	if err != nil {
+		return value, nil
+	}
+	return t.Round(time.Millisecond), nil
+}
+
+func (sheet *xlsxSheet) isDateStyle(styleID int) bool {
+	if isDate, ok := sheet.isDate[styleID]; ok {
+		return isDate
+	}
+	isDate := false
+	// GetStyle ensures the style sheet is loaded before reading the raw number format
+	if _, err := sheet.file.GetStyle(styleID); err == nil && sheet.file.Styles != nil && sheet.file.Styles.CellXfs != nil && styleID < len(sheet.file.Styles.CellXfs.Xf) {
+		if numFmtID := sheet.file.Styles.CellXfs.Xf[styleID].NumFmtID; numFmtID != nil {
+			isDate = xlsxDateFormatIDs[*numFmtID]
+			if !isDate && sheet.file.Styles.NumFmts != nil {
+				for _, numFmt := range sheet.file.Styles.NumFmts.NumFmt {
+					if numFmt.NumFmtID == *numFmtID {
+						isDate = isXLSXDateFormatCode(numFmt.FormatCode)
+					}
+				}
+			}
+		}
+	}
+	sheet.isDate[styleID] = isDate
+	return isDate
+}
+
+func isXLSXDateFormatCode(formatCode string) bool {
+	formatCode = strings.ToLower(formatCode)
+	if strings.Contains(formatCode, "[h]") || strings.Contains(formatCode, "[m]") || strings.Contains(formatCode, "[s]") {
+		return true
+	}
+	return strings.ContainsAny(xlsxFormatLiteralRegex.ReplaceAllString(formatCode, ""), "ydhms")
+}
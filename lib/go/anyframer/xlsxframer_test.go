@@ -0,0 +1,96 @@
+package anyframer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/experimental"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+)
+
+func testXLSX(t *testing.T) string {
+	t.Helper()
+	f := excelize.NewFile()
+	defer f.Close()
+	require.Nil(t, f.SetSheetRow("Sheet1", "A1", &[]any{"name", "salary", "joined", "updated", "notes"}))
+	require.Nil(t, f.SetSheetRow("Sheet1", "A2", &[]any{"foo", 123, 44927, 45000.5, "hello"}))
+	require.Nil(t, f.SetSheetRow("Sheet1", "A3", &[]any{"bar", 456.789, 44958, 45001.25}))
+	dateStyle, err := f.NewStyle(&excelize.Style{NumFmt: 14})
+	require.Nil(t, err)
+	customFormat := "yyyy-mm-dd hh:mm"
+	customStyle, err := f.NewStyle(&excelize.Style{CustomNumFmt: &customFormat})
+	require.Nil(t, err)
+	require.Nil(t, f.SetCellStyle("Sheet1", "C2", "C3", dateStyle))
+	require.Nil(t, f.SetCellStyle("Sheet1", "D2", "D3", customStyle))
+	_, err = f.NewSheet("Summary")
+	require.Nil(t, err)
+	require.Nil(t, f.SetSheetRow("Summary", "B3", &[]any{"region", "total"}))
+	require.Nil(t, f.SetSheetRow("Summary", "B4", &[]any{"emea", 10}))
+	require.Nil(t, f.SetSheetRow("Summary", "B5", &[]any{"apac", 20}))
+	require.Nil(t, f.SetSheetRow("Summary", "B6", &[]any{"total", 30}))
+	b, err := f.WriteToBuffer()
+	require.Nil(t, err)
+	return b.String()
+}
+
+func TestToFrame_XLSX(t *testing.T) {
+	input := testXLSX(t)
+	t.Run("xlsx", func(t *testing.T) {
+		framer := Framer{RawURL: "https://foo.com/users.xlsx"}
+		frame, err := framer.ToFrame(input)
+		require.Nil(t, err)
+		require.Equal(t, anyframer.InputTypeXLSX, framer.InputType)
+		experimental.CheckGoldenJSONFrame(t, "testdata/golden", t.Name(), frame, updateGoldenFile)
+	})
+	t.Run("xlsx with columns", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeXLSX, Columns: []anyframer.Column{
+			{Selector: "name", Alias: "Name", Format: anyframer.ColumnFormatString},
+			{Selector: "salary", Format: anyframer.ColumnFormatNumber},
+			{Selector: "joined", Format: anyframer.ColumnFormatTimeStamp},
+		}}
+		frame, err := framer.ToFrame([]byte(input))
+		require.Nil(t, err)
+		require.Equal(t, 3, len(frame.Fields))
+		name, _ := frame.Fields[0].ConcreteAt(1)
+		require.Equal(t, "bar", name)
+		salary, _ := frame.Fields[1].ConcreteAt(1)
+		require.Equal(t, 456.789, salary)
+		joined, _ := frame.Fields[2].ConcreteAt(0)
+		require.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), joined)
+	})
+	t.Run("xlsx with sheet and range", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeXLSX, XLSXOptions: anyframer.XLSXOptions{Sheet: "summary", Range: "B3:C5"}}
+		frame, err := framer.ToFrame(input)
+		require.Nil(t, err)
+		experimental.CheckGoldenJSONFrame(t, "testdata/golden", t.Name(), frame, updateGoldenFile)
+	})
+	t.Run("xlsx with sheet index and no headers", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeXLSX, XLSXOptions: anyframer.XLSXOptions{SheetIndex: 1, Range: "$B$4:$C$6", NoHeaders: true}}
+		frame, err := framer.ToFrame(input)
+		require.Nil(t, err)
+		experimental.CheckGoldenJSONFrame(t, "testdata/golden", t.Name(), frame, updateGoldenFile)
+	})
+	t.Run("invalid sheet should throw error", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeXLSX, XLSXOptions: anyframer.XLSXOptions{Sheet: "foo"}}
+		_, err := framer.ToFrame(input)
+		require.EqualError(t, err, `invalid xlsx sheet "foo"`)
+	})
+	t.Run("invalid sheet index should throw error", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeXLSX, XLSXOptions: anyframer.XLSXOptions{SheetIndex: 2}}
+		_, err := framer.ToFrame(input)
+		require.EqualError(t, err, "invalid xlsx sheet index 2. 2 sheet(s) found")
+	})
+	t.Run("invalid range should throw error", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeXLSX, XLSXOptions: anyframer.XLSXOptions{Range: "A1"}}
+		_, err := framer.ToFrame(input)
+		require.EqualError(t, err, `invalid xlsx range "A1"`)
+	})
+	t.Run("invalid xlsx should throw error", func(t *testing.T) {
+		framer := Framer{InputType: anyframer.InputTypeXLSX}
+		_, err := framer.ToFrame("foo")
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "error reading xlsx.")
+	})
+}
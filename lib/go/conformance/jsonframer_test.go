@@ -0,0 +1,76 @@
+package conformance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/jsonframer"
+)
+
+// TestColumnTypeConformance ensures the column type conversions of jsonframer are same as anyframer.SliceToFrame
+func TestColumnTypeConformance(t *testing.T) {
+	input := `[
+		{ "s": "foo", "n": "1.5", "b": "TRUE", "t": "2011-01-01T00:00:00Z", "tf": "2023/01/02", "tn": 20230102, "y": 2011, "e": 1262304000000, "es": 1262304000 },
+		{ "s": 12.5, "n": 3, "b": false, "t": "2012-06-01 10:20:30", "tf": "2023/02/03", "tn": 20230203, "y": "2013-01-01", "e": "1293840000000", "es": "1293840000" },
+		{ "s": true, "n": "abc", "b": " false ", "t": "2011-01-01T00:00:00.123456Z", "tf": null, "tn": null, "y": "foo", "e": null, "es": true },
+		{ "s": null, "n": true, "b": 1, "t": null, "tf": "", "tn": "20230304", "y": null, "e": "abc", "es": 1.5 }
+	]`
+	tests := []struct {
+		name    string
+		columns []jsonframer.ColumnSelector
+		wantErr string
+	}{
+		{name: "string", columns: []jsonframer.ColumnSelector{{Selector: "s", Type: "string"}, {Selector: "n", Alias: "n as string", Type: "string"}}},
+		{name: "number", columns: []jsonframer.ColumnSelector{{Selector: "n", Type: "number"}, {Selector: "s", Alias: "s as number", Type: "number"}}},
+		{name: "boolean", columns: []jsonframer.ColumnSelector{{Selector: "b", Type: "boolean"}, {Selector: "s", Alias: "s as boolean", Type: "boolean"}}},
+		{name: "timestamp", columns: []jsonframer.ColumnSelector{{Selector: "t", Type: "timestamp"}, {Selector: "y", Type: "timestamp"}}},
+		{name: "timestamp with moment format", columns: []jsonframer.ColumnSelector{{Selector: "tf", Type: "timestamp", TimeFormat: "YYYY/MM/DD"}}},
+		{name: "timestamp with strftime format", columns: []jsonframer.ColumnSelector{{Selector: "tf", Type: "timestamp", TimeFormat: "%Y/%m/%d"}}},
+		{name: "timestamp with go layout", columns: []jsonframer.ColumnSelector{{Selector: "tn", Type: "timestamp", TimeFormat: "20060102"}}},
+		{name: "timestamp_epoch", columns: []jsonframer.ColumnSelector{{Selector: "e", Type: "timestamp_epoch"}}},
+		{name: "timestamp_epoch_s", columns: []jsonframer.ColumnSelector{{Selector: "es", Type: "timestamp_epoch_s"}}},
+		{name: "timestamp not matching format should throw error", columns: []jsonframer.ColumnSelector{{Selector: "t", Type: "timestamp", TimeFormat: "YYYY/MM/DD"}}, wantErr: `invalid time value "2011-01-01T00:00:00Z" for column "t". expected format "YYYY/MM/DD"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := []anyframer.Column{}
+			for _, c := range tt.columns {
+				columns = append(columns, anyframer.Column{Selector: c.Selector, Alias: c.Alias, Format: anyframer.ColumnFormat(c.Type), TimeFormat: c.TimeFormat})
+			}
+			framer := anyframer.AnyFramer{InputType: anyframer.InputTypeJSON, Columns: columns}
+			wantFrame, wantErr := framer.ToFrame(input)
+			for _, selectorType := range []jsonframer.SelectorType{jsonframer.SelectorTypeGJSON, jsonframer.SelectorTypeJSONata, jsonframer.SelectorTypeJSONPath} {
+				t.Run(string(selectorType), func(t *testing.T) {
+					gotFrame, err := jsonframer.ToFrame(input, jsonframer.FramerOptions{SelectorType: selectorType, Columns: tt.columns})
+					if tt.wantErr != "" {
+						require.EqualError(t, wantErr, tt.wantErr)
+						require.EqualError(t, err, tt.wantErr)
+						return
+					}
+					require.Nil(t, wantErr)
+					require.Nil(t, err)
+					require.Equal(t, len(wantFrame.Fields), len(gotFrame.Fields))
+					for _, wantField := range wantFrame.Fields {
+						gotField, _ := gotFrame.FieldByName(wantField.Name)
+						require.NotNil(t, gotField, wantField.Name)
+						require.Equal(t, wantField.Type(), gotField.Type(), wantField.Name)
+						require.Equal(t, wantField.Len(), gotField.Len(), wantField.Name)
+						for i := 0; i < wantField.Len(); i++ {
+							want, wantOk := wantField.ConcreteAt(i)
+							got, gotOk := gotField.ConcreteAt(i)
+							require.Equal(t, wantOk, gotOk, "%s[%d]: want %v, got %v", wantField.Name, i, want, got)
+							if wantTime, ok := want.(time.Time); ok {
+								require.IsType(t, wantTime, got, "%s[%d]", wantField.Name, i)
+								require.True(t, wantTime.Equal(got.(time.Time)), "%s[%d]: want %v, got %v", wantField.Name, i, want, got)
+								continue
+							}
+							require.Equal(t, want, got, "%s[%d]", wantField.Name, i)
+						}
+					}
+				})
+			}
+		})
+	}
+}
@@ -1,10 +1,7 @@
 package csvframer
 
 import (
-	"encoding/csv"
 	"errors"
-	"fmt"
-	"io"
 	"strings"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
@@ -15,71 +12,40 @@ type FramerOptions struct {
 	FrameName          string
 	Columns            []gframer.ColumnSelector
 	Delimiter          string
+	Quote              string
+	Encoding           Encoding
+	SkipRows           int
 	SkipLinesWithError bool
 	Comment            string
 	RelaxColumnCount   bool
 	NoHeaders          bool
+	Headers            []string
 }
 
 func ToFrame(csvString string, options FramerOptions) (frame *data.Frame, err error) {
 	if strings.TrimSpace(csvString) == "" {
 		return frame, errors.New("empty/invalid csv")
 	}
-	r := csv.NewReader(strings.NewReader(csvString))
-	r.LazyQuotes = true
-	if options.Comment != "" {
-		r.Comment = rune(options.Comment[0])
-	}
-	if options.Delimiter != "" {
-		r.Comma = rune(options.Delimiter[0])
-	}
-	if options.RelaxColumnCount {
-		r.FieldsPerRecord = -1
-	}
-	parsedCSV := [][]string{}
-	for {
-		record, err := r.Read()
-		if err == io.EOF {
-			break
-		}
-		if err == nil {
-			parsedCSV = append(parsedCSV, record)
-			continue
-		}
-		if !options.SkipLinesWithError {
-			return frame, fmt.Errorf("error reading csv response. %w, %v", err, record)
+	aliases := map[string]string{}
+	for _, col := range options.Columns {
+		if col.Alias != "" {
+			aliases[col.Selector] = col.Alias
 		}
 	}
-	out := []interface{}{}
-	header := []string{}
-	records := [][]string{}
-	if !options.NoHeaders {
-		header = parsedCSV[0]
-		for idx, hItem := range header {
-			for _, col := range options.Columns {
-				if col.Selector == hItem && col.Alias != "" {
-					header[idx] = col.Alias
-				}
-			}
-		}
-		records = parsedCSV[1:]
-	}
-	if options.NoHeaders {
-		records = parsedCSV
-		if len(records) > 0 {
-			for i := 0; i < len(records[0]); i++ {
-				header = append(header, fmt.Sprintf("%d", i+1))
-			}
-		}
-	}
-	for _, row := range records {
-		item := map[string]interface{}{}
-		for colId, col := range header {
-			if colId < len(row) {
-				item[col] = row[colId]
-			}
-		}
-		out = append(out, item)
+	_, out, err := Parse(csvString, ParseOptions{
+		Delimiter:          options.Delimiter,
+		Quote:              options.Quote,
+		Comment:            options.Comment,
+		Encoding:           options.Encoding,
+		SkipRows:           options.SkipRows,
+		RelaxColumnCount:   options.RelaxColumnCount,
+		SkipLinesWithError: options.SkipLinesWithError,
+		NoHeaders:          options.NoHeaders,
+		Headers:            options.Headers,
+		Aliases:            aliases,
+	})
+	if err != nil {
+		return frame, err
 	}
 	framerOptions := gframer.FramerOptions{
 		FrameName: options.FrameName,
@@ -73,6 +73,12 @@ func TestCsvStringToFrame(t *testing.T) {
 			csvString: strings.Join([]string{`# foo`, `a,b,c`, `#01,02,03`, `1,2,3`, `11,12,13`, `21,22,23`, `#`}, "\n"),
 			options:   csvframer.FramerOptions{Comment: "#"},
 		},
+		{
+			name:      "only comments should return error",
+			csvString: strings.Join([]string{`# foo`, `# bar`}, "\n"),
+			options:   csvframer.FramerOptions{Comment: "#"},
+			wantError: errors.New("invalid/empty csv"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,273 @@
+package csvframer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// Encoding is the character encoding of the csv input
+type Encoding string
+
+const (
+	// EncodingUTF8 is the default encoding
+	EncodingUTF8 Encoding = "utf-8"
+	// EncodingUTF16 detects the byte order from the BOM and defaults to little endian
+	EncodingUTF16   Encoding = "utf-16"
+	EncodingUTF16LE Encoding = "utf-16le"
+	EncodingUTF16BE Encoding = "utf-16be"
+	// EncodingLatin1 is ISO-8859-1
+	EncodingLatin1 Encoding = "latin1"
+)
+
+// ParseOptions ...
+// Delimiter and Comment can be multiple characters. Quote must be a single character and defaults to double quote.
+// SkipRows lines are skipped before parsing. Headers are used as header instead of the first record.
+// Aliases renames the header
+type ParseOptions struct {
+	Delimiter          string
+	Quote              string
+	Comment            string
+	Encoding           Encoding
+	SkipRows           int
+	RelaxColumnCount   bool
+	SkipLinesWithError bool
+	NoHeaders          bool
+	Headers            []string
+	Aliases            map[string]string
+}
+
+// Parse parses the csv string and returns the header and the rows as objects keyed by the header.
+// Quotes are lazy, empty lines are skipped and the BOM is removed
+func Parse(csvString string, options ParseOptions) (header []string, rows []any, err error) {
+	csvString, err = decode(csvString, options.Encoding)
+	if err != nil {
+		return nil, nil, err
+	}
+	delimiter := options.Delimiter
+	if delimiter == "" {
+		delimiter = ","
+	}
+	quote := options.Quote
+	if quote == "" {
+		quote = `"`
+	}
+	if utf8.RuneCountInString(quote) != 1 || quote == "\n" || quote == "\r" {
+		return nil, nil, fmt.Errorf("invalid quote character %q", quote)
+	}
+	if strings.ContainsAny(delimiter, "\r\n") || strings.Contains(delimiter, quote) {
+		return nil, nil, fmt.Errorf("invalid delimiter %q", delimiter)
+	}
+	r := &reader{input: csvString, delimiter: delimiter, quote: quote, comment: options.Comment}
+	r.skipLines(options.SkipRows)
+	for _, h := range options.Headers {
+		h = strings.TrimSpace(h)
+		if len(h) >= 2*len(quote) && strings.HasPrefix(h, quote) && strings.HasSuffix(h, quote) {
+			h = h[len(quote) : len(h)-len(quote)]
+		}
+		header = append(header, h)
+	}
+	fieldsPerRecord := len(header)
+	records := [][]string{}
+	for {
+		record, line := r.readRecord()
+		if record == nil {
+			break
+		}
+		if !options.RelaxColumnCount {
+			if fieldsPerRecord == 0 {
+				fieldsPerRecord = len(record)
+			}
+			if len(record) != fieldsPerRecord {
+				if !options.SkipLinesWithError {
+					err := &csv.ParseError{StartLine: line, Line: line, Column: 1, Err: csv.ErrFieldCount}
+					return nil, nil, fmt.Errorf("error reading csv response. %w, %v", err, record)
+				}
+				continue
+			}
+		}
+		records = append(records, record)
+	}
+	if len(header) == 0 {
+		if len(records) == 0 {
+			return nil, nil, errors.New("invalid/empty csv")
+		}
+		if options.NoHeaders {
+			for i := 0; i < len(records[0]); i++ {
+				header = append(header, fmt.Sprintf("%d", i+1))
+			}
+		} else {
+			header, records = records[0], records[1:]
+		}
+	}
+	if !options.NoHeaders || len(options.Headers) > 0 {
+		for idx, h := range header {
+			if alias, ok := options.Aliases[h]; ok && alias != "" {
+				header[idx] = alias
+			}
+		}
+	}
+	rows = []any{}
+	for _, record := range records {
+		item := map[string]any{}
+		for hID, h := range header {
+			if hID < len(record) {
+				item[h] = record[hID]
+			}
+		}
+		rows = append(rows, item)
+	}
+	return header, rows, nil
+}
+
+func decode(input string, enc Encoding) (string, error) {
+	var decoder *encoding.Decoder
+	switch Encoding(strings.ToLower(string(enc))) {
+	case "", EncodingUTF8, "utf8":
+	case EncodingUTF16, "utf16":
+		decoder = unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewDecoder()
+	case EncodingUTF16LE:
+		decoder = unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewDecoder()
+	case EncodingUTF16BE:
+		decoder = unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewDecoder()
+	case EncodingLatin1, "iso-8859-1":
+		decoder = charmap.ISO8859_1.NewDecoder()
+	default:
+		return "", fmt.Errorf("unsupported csv encoding %q", enc)
+	}
+	if decoder != nil {
+		decoded, err := decoder.String(input)
+		if err != nil {
+			return "", fmt.Errorf("error decoding csv using %s encoding. %w", enc, err)
+		}
+		input = decoded
+	}
+	return strings.TrimPrefix(input, "\ufeff"), nil
+}
+
+// reader reads the records of the csv similar to encoding/csv with lazy quotes. Unlike encoding/csv, delimiter and comment can be multiple characters
+type reader struct {
+	input     string
+	pos       int
+	line      int
+	delimiter string
+	quote     string
+	comment   string
+}
+
+func (r *reader) skipLines(n int) {
+	for i := 0; i < n && r.pos < len(r.input); i++ {
+		r.skipLine()
+	}
+}
+
+func (r *reader) skipLine() {
+	r.line++
+	idx := strings.IndexByte(r.input[r.pos:], '\n')
+	if idx < 0 {
+		r.pos = len(r.input)
+		return
+	}
+	r.pos += idx + 1
+}
+
+// endOfLine consumes the line ending at the current position and reports whether the line ended
+func (r *reader) endOfLine() bool {
+	switch {
+	case r.pos >= len(r.input):
+		return true
+	case r.input[r.pos] == '\n':
+		r.pos++
+	case strings.HasPrefix(r.input[r.pos:], "\r\n"):
+		r.pos += 2
+	case r.input[r.pos] == '\r' && r.pos+1 == len(r.input):
+		r.pos++
+	default:
+		return false
+	}
+	return true
+}
+
+// readRecord returns the next record and its line number. Record is nil when there are no more records
+func (r *reader) readRecord() ([]string, int) {
+	for r.pos < len(r.input) {
+		if r.comment != "" && strings.HasPrefix(r.input[r.pos:], r.comment) {
+			r.skipLine()
+			continue
+		}
+		if !r.endOfLine() {
+			break
+		}
+		r.line++
+	}
+	if r.pos >= len(r.input) {
+		return nil, 0
+	}
+	r.line++
+	line := r.line
+	record := []string{}
+	for {
+		field, more := r.readField()
+		record = append(record, field)
+		if !more {
+			return record, line
+		}
+	}
+}
+
+// readField returns the field at the current position and reports whether more fields follow in the record
+func (r *reader) readField() (string, bool) {
+	if !strings.HasPrefix(r.input[r.pos:], r.quote) {
+		start := r.pos
+		for {
+			end := r.pos
+			if strings.HasPrefix(r.input[r.pos:], r.delimiter) {
+				r.pos += len(r.delimiter)
+				return r.input[start:end], true
+			}
+			if r.endOfLine() {
+				return r.input[start:end], false
+			}
+			r.pos++
+		}
+	}
+	r.pos += len(r.quote)
+	var field strings.Builder
+	for r.pos < len(r.input) {
+		if strings.HasPrefix(r.input[r.pos:], r.quote) {
+			r.pos += len(r.quote)
+			switch {
+			case strings.HasPrefix(r.input[r.pos:], r.quote):
+				r.pos += len(r.quote)
+			case strings.HasPrefix(r.input[r.pos:], r.delimiter):
+				r.pos += len(r.delimiter)
+				return field.String(), true
+			case r.endOfLine():
+				return field.String(), false
+			}
+			// escaped or lazy quote
+			field.WriteString(r.quote)
+			continue
+		}
+		if r.input[r.pos:] == "\r" {
+			r.pos++
+			break
+		}
+		if strings.HasPrefix(r.input[r.pos:], "\r\n") {
+			r.pos++
+		}
+		if r.input[r.pos] == '\n' {
+			r.line++
+		}
+		field.WriteByte(r.input[r.pos])
+		r.pos++
+	}
+	// unterminated quoted field ends with the input
+	return field.String(), false
+}
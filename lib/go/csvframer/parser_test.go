@@ -0,0 +1,213 @@
+package csvframer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/csvframer"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+func encode(t *testing.T, input string, encoder interface {
+	String(string) (string, error)
+}) string {
+	t.Helper()
+	out, err := encoder.String(input)
+	require.Nil(t, err)
+	return out
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		csvString  string
+		options    csvframer.ParseOptions
+		wantHeader []string
+		wantRows   []any
+		wantErr    string
+	}{
+		{
+			name:       "basic csv",
+			csvString:  "a,b\n1,2\n3,4\n",
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": "1", "b": "2"}, map[string]any{"a": "3", "b": "4"}},
+		},
+		{
+			name:       "crlf line endings and empty lines",
+			csvString:  "a,b\r\n\r\n1,2\r\n\n3,4\r",
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": "1", "b": "2"}, map[string]any{"a": "3", "b": "4"}},
+		},
+		{
+			name:       "quoted fields",
+			csvString:  "a,b\n\"foo, bar\",\"say \"\"hi\"\"\"\n\"multi\r\nline\",\"x\"y\"\n",
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": "foo, bar", "b": `say "hi"`}, map[string]any{"a": "multi\nline", "b": `x"y`}},
+		},
+		{
+			name:       "bare quotes and unterminated quotes are lazy",
+			csvString:  "a,b\nfo\"o,\"bar",
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": `fo"o`, "b": "bar"}},
+		},
+		{
+			name:       "multi character delimiter",
+			csvString:  "a||b||c\n1||\"2||3\"||4\n5|6||7||",
+			options:    csvframer.ParseOptions{Delimiter: "||"},
+			wantHeader: []string{"a", "b", "c"},
+			wantRows:   []any{map[string]any{"a": "1", "b": "2||3", "c": "4"}, map[string]any{"a": "5|6", "b": "7", "c": ""}},
+		},
+		{
+			name:       "quote character",
+			csvString:  "a;b\n'foo;bar';'it''s'\n\"x\";y",
+			options:    csvframer.ParseOptions{Delimiter: ";", Quote: "'"},
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": "foo;bar", "b": "it's"}, map[string]any{"a": `"x"`, "b": "y"}},
+		},
+		{
+			name:       "comments",
+			csvString:  "// generated\na,b\n//1,2\n3,4",
+			options:    csvframer.ParseOptions{Comment: "//"},
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": "3", "b": "4"}},
+		},
+		{
+			name:       "skip rows",
+			csvString:  "Report\nGenerated at 2023-01-01, by foo\na,b\n1,2",
+			options:    csvframer.ParseOptions{SkipRows: 2},
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": "1", "b": "2"}},
+		},
+		{
+			name:      "skip rows error should report the original line",
+			csvString: "Report\na,b\n1,2\n3",
+			options:   csvframer.ParseOptions{SkipRows: 1},
+			wantErr:   "error reading csv response. record on line 4: wrong number of fields, [3]",
+		},
+		{
+			name:       "skip lines with error",
+			csvString:  "a,b\n1,2\n3\n\"4\n\",5",
+			options:    csvframer.ParseOptions{SkipLinesWithError: true},
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": "1", "b": "2"}, map[string]any{"a": "4\n", "b": "5"}},
+		},
+		{
+			name:       "relax column count",
+			csvString:  "a,b\n1\n2,3,4",
+			options:    csvframer.ParseOptions{RelaxColumnCount: true},
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": "1"}, map[string]any{"a": "2", "b": "3"}},
+		},
+		{
+			name:       "no headers",
+			csvString:  "1,2\n3,4",
+			options:    csvframer.ParseOptions{NoHeaders: true},
+			wantHeader: []string{"1", "2"},
+			wantRows:   []any{map[string]any{"1": "1", "2": "2"}, map[string]any{"1": "3", "2": "4"}},
+		},
+		{
+			name:       "headers",
+			csvString:  "1,2\n3,4",
+			options:    csvframer.ParseOptions{Headers: []string{" a ", `"b"`}, Aliases: map[string]string{"b": "B"}},
+			wantHeader: []string{"a", "B"},
+			wantRows:   []any{map[string]any{"a": "1", "B": "2"}, map[string]any{"a": "3", "B": "4"}},
+		},
+		{
+			name:      "headers should set the column count",
+			csvString: "1,2,3",
+			options:   csvframer.ParseOptions{Headers: []string{"a", "b"}},
+			wantErr:   "error reading csv response. record on line 1: wrong number of fields, [1 2 3]",
+		},
+		{
+			name:       "aliases",
+			csvString:  "a,b\n1,2",
+			options:    csvframer.ParseOptions{Aliases: map[string]string{"a": "A", "c": "C"}},
+			wantHeader: []string{"A", "b"},
+			wantRows:   []any{map[string]any{"A": "1", "b": "2"}},
+		},
+		{
+			name:       "header only",
+			csvString:  "a,b",
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{},
+		},
+		{
+			name:      "comments only should throw error",
+			csvString: "#a,b\n#1,2",
+			options:   csvframer.ParseOptions{Comment: "#"},
+			wantErr:   "invalid/empty csv",
+		},
+		{
+			name:       "utf-8 bom",
+			csvString:  "\ufeffa,b\n1,2",
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": "1", "b": "2"}},
+		},
+		{
+			name:       "utf-16 with bom",
+			csvString:  encode(t, "näme,b\r\nfoo,2", unicode.UTF16(unicode.BigEndian, unicode.UseBOM).NewEncoder()),
+			options:    csvframer.ParseOptions{Encoding: csvframer.EncodingUTF16},
+			wantHeader: []string{"näme", "b"},
+			wantRows:   []any{map[string]any{"näme": "foo", "b": "2"}},
+		},
+		{
+			name:       "utf-16 without bom defaults to little endian",
+			csvString:  encode(t, "a\tb\n€\t2", unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder()),
+			options:    csvframer.ParseOptions{Encoding: csvframer.EncodingUTF16, Delimiter: "\t"},
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": "€", "b": "2"}},
+		},
+		{
+			name:       "utf-16le with bom",
+			csvString:  encode(t, "\ufeffa,b\n1,2", unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder()),
+			options:    csvframer.ParseOptions{Encoding: csvframer.EncodingUTF16LE},
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": "1", "b": "2"}},
+		},
+		{
+			name:       "utf-16be",
+			csvString:  encode(t, "a,b\n1,2", unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewEncoder()),
+			options:    csvframer.ParseOptions{Encoding: "UTF-16BE"},
+			wantHeader: []string{"a", "b"},
+			wantRows:   []any{map[string]any{"a": "1", "b": "2"}},
+		},
+		{
+			name:       "latin1",
+			csvString:  encode(t, "city,temp\nMünchen,12°", charmap.ISO8859_1.NewEncoder()),
+			options:    csvframer.ParseOptions{Encoding: csvframer.EncodingLatin1},
+			wantHeader: []string{"city", "temp"},
+			wantRows:   []any{map[string]any{"city": "München", "temp": "12°"}},
+		},
+		{
+			name:      "unsupported encoding should throw error",
+			csvString: "a,b",
+			options:   csvframer.ParseOptions{Encoding: "ebcdic"},
+			wantErr:   `unsupported csv encoding "ebcdic"`,
+		},
+		{
+			name:      "invalid quote should throw error",
+			csvString: "a,b",
+			options:   csvframer.ParseOptions{Quote: "''"},
+			wantErr:   `invalid quote character "''"`,
+		},
+		{
+			name:      "delimiter with quote should throw error",
+			csvString: "a,b",
+			options:   csvframer.ParseOptions{Delimiter: `","`},
+			wantErr:   `invalid delimiter "\",\""`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotHeader, gotRows, err := csvframer.Parse(tt.csvString, tt.options)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantHeader, gotHeader)
+			require.Equal(t, tt.wantRows, gotRows)
+		})
+	}
+}
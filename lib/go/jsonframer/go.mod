@@ -11,6 +11,9 @@ require (
 	github.com/tidwall/gjson v1.17.0
 	github.com/xiatechs/jsonata-go v1.7.1
 	github.com/yesoreyeram/grafana-plugins/lib/go/gframer v0.0.1
+	github.com/yesoreyeram/grafana-plugins/lib/go/macros v0.2.1
+	github.com/yesoreyeram/grafana-plugins/lib/go/utils v0.0.1
+	modernc.org/sqlite v1.27.0
 )
 
 require (
@@ -96,7 +99,6 @@ require (
 	github.com/unknwon/com v1.0.1 // indirect
 	github.com/unknwon/log v0.0.0-20200308114134-929b1006e34a // indirect
 	github.com/urfave/cli v1.22.14 // indirect
-	github.com/yesoreyeram/grafana-plugins/lib/go/utils v0.0.1 // indirect
 	github.com/zeebo/xxh3 v1.0.2 // indirect
 	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc v0.46.1 // indirect
 	go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace v0.46.1 // indirect
@@ -132,7 +134,6 @@ require (
 	modernc.org/mathutil v1.6.0 // indirect
 	modernc.org/memory v1.7.2 // indirect
 	modernc.org/opt v0.1.3 // indirect
-	modernc.org/sqlite v1.27.0 // indirect
 	modernc.org/strutil v1.2.0 // indirect
 	modernc.org/token v1.1.0 // indirect
 )
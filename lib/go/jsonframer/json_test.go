@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/grafana/grafana-plugin-sdk-go/experimental"
@@ -311,3 +312,160 @@ func TestAzureFrame(t *testing.T) {
 	require.NotNil(t, gotFrame)
 	experimental.CheckGoldenJSONFrame(t, "testdata/azure", "cost-management-daily", gotFrame, false)
 }
+
+func TestSelectorType(t *testing.T) {
+	responseString := `{ "users": [ { "name": "foo", "age": 12 }, { "name": "bar", "age": 30 } ], "empty": [] }`
+	tests := []struct {
+		name         string
+		selectorType jsonframer.SelectorType
+		rootSelector string
+		columns      []jsonframer.ColumnSelector
+		wantRows     int
+		wantErr      string
+	}{
+		{name: "auto gjson path", rootSelector: "users", wantRows: 2},
+		{name: "auto jsonata expression", rootSelector: "users[age > 20]", wantRows: 1},
+		{name: "auto invalid selector should throw error instead of panic", rootSelector: "users[", wantErr: `invalid root selector "users[". root selector is neither an existing gjson path nor a valid jsonata expression.`},
+		{name: "gjson path", selectorType: jsonframer.SelectorTypeGJSON, rootSelector: "users.#(age>20)#", wantRows: 1},
+		{name: "gjson missing path should throw error", selectorType: jsonframer.SelectorTypeGJSON, rootSelector: "foo", wantErr: "root object doesn't exist in the response. Root selector:foo"},
+		{name: "jsonata expression", selectorType: jsonframer.SelectorTypeJSONata, rootSelector: "users[age > 10]", wantRows: 2},
+		{name: "jsonata with existing gjson path", selectorType: jsonframer.SelectorTypeJSONata, rootSelector: "$count(empty)", wantRows: 1},
+		{name: "jsonata column selectors", selectorType: jsonframer.SelectorTypeJSONata, rootSelector: "users", columns: []jsonframer.ColumnSelector{{Selector: "$uppercase(name)", Alias: "name"}, {Selector: "age * 2", Alias: "double", Type: "number"}}, wantRows: 2},
+		{name: "jsonata invalid selector should throw error", selectorType: jsonframer.SelectorTypeJSONata, rootSelector: "users[", wantErr: `invalid jsonata root selector "users[".`},
+		{name: "jsonata invalid column selector should throw error", selectorType: jsonframer.SelectorTypeJSONata, rootSelector: "users", columns: []jsonframer.ColumnSelector{{Selector: "name(", Alias: "name"}}, wantErr: `invalid jsonata selector "name(" for column "name".`},
+		{name: "jsonpath filter", selectorType: jsonframer.SelectorTypeJSONPath, rootSelector: "$.users[?(@.age > 20)]", wantRows: 1},
+		{name: "jsonpath slice", selectorType: jsonframer.SelectorTypeJSONPath, rootSelector: "$.users[0:2]", wantRows: 2},
+		{name: "jsonpath recursive descent", selectorType: jsonframer.SelectorTypeJSONPath, rootSelector: "$..name", wantRows: 2},
+		{name: "jsonpath column selectors", selectorType: jsonframer.SelectorTypeJSONPath, rootSelector: "$.users", columns: []jsonframer.ColumnSelector{{Selector: "$.name", Alias: "name"}, {Selector: "['age']", Alias: "age", Type: "number"}}, wantRows: 2},
+		{name: "jsonpath filter with single match returns node list", selectorType: jsonframer.SelectorTypeJSONPath, rootSelector: "$.users[?(@.name == 'bar')]", wantRows: 1},
+		{name: "jsonpath filter without match returns empty node list", selectorType: jsonframer.SelectorTypeJSONPath, rootSelector: "$.users[?(@.age > 100)]", wantRows: 0},
+		{name: "jsonpath singular path returns the node", selectorType: jsonframer.SelectorTypeJSONPath, rootSelector: "$.users[1]", wantRows: 1},
+		{name: "jsonpath missing path should throw error", selectorType: jsonframer.SelectorTypeJSONPath, rootSelector: "$.foo", wantErr: "root object doesn't exist in the response. Root selector:$.foo"},
+		{name: "jsonpath invalid selector should throw error", selectorType: jsonframer.SelectorTypeJSONPath, rootSelector: "$.users[?@.age = 1]", wantErr: `invalid jsonpath "$.users[?@.age = 1]".`},
+		{name: "jsonpath invalid column selector should throw error", selectorType: jsonframer.SelectorTypeJSONPath, rootSelector: "$.users", columns: []jsonframer.ColumnSelector{{Selector: "$..", Alias: "name"}}, wantErr: `invalid jsonpath selector for column "name".`},
+		{name: "invalid selector type should throw error", selectorType: "foo", rootSelector: "users", wantErr: `invalid selector type "foo"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotFrame, err := jsonframer.ToFrame(responseString, jsonframer.FramerOptions{
+				SelectorType: tt.selectorType,
+				RootSelector: tt.rootSelector,
+				Columns:      tt.columns,
+			})
+			if tt.wantErr != "" {
+				require.NotNil(t, err)
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.NotNil(t, gotFrame)
+			require.Equal(t, tt.wantRows, gotFrame.Rows())
+		})
+	}
+	t.Run("jsonata column values", func(t *testing.T) {
+		gotFrame, err := jsonframer.ToFrame(responseString, jsonframer.FramerOptions{
+			SelectorType: jsonframer.SelectorTypeJSONata,
+			RootSelector: "users",
+			Columns:      []jsonframer.ColumnSelector{{Selector: "$uppercase(name)", Alias: "name"}, {Selector: "age * 2", Alias: "double", Type: "number"}},
+		})
+		require.Nil(t, err)
+		name, _ := gotFrame.FieldByName("name")
+		require.NotNil(t, name)
+		value, _ := name.ConcreteAt(0)
+		require.Equal(t, "FOO", value)
+		double, _ := gotFrame.FieldByName("double")
+		require.NotNil(t, double)
+		value, _ = double.ConcreteAt(1)
+		require.Equal(t, 60.0, value)
+	})
+	t.Run("jsonpath column values", func(t *testing.T) {
+		gotFrame, err := jsonframer.ToFrame(`{"items":[{"name":"foo","status":"up","tags":["a","b"]},{"name":"bar","status":"down"},{"name":"baz","status":"up","tags":[]}]}`, jsonframer.FramerOptions{
+			SelectorType: jsonframer.SelectorTypeJSONPath,
+			RootSelector: "$.items[?(@.status=='up')]",
+			Columns:      []jsonframer.ColumnSelector{{Selector: "$.name", Alias: "name"}, {Selector: "tags[*]", Alias: "tags"}},
+		})
+		require.Nil(t, err)
+		require.Equal(t, 2, gotFrame.Rows())
+		name, _ := gotFrame.FieldByName("name")
+		require.NotNil(t, name)
+		value, _ := name.ConcreteAt(1)
+		require.Equal(t, "baz", value)
+		tags, _ := gotFrame.FieldByName("tags")
+		require.NotNil(t, tags)
+		value, _ = tags.ConcreteAt(0)
+		require.Equal(t, `["a","b"]`, value)
+	})
+	t.Run("jsonpath non singular selectors return node list", func(t *testing.T) {
+		gotFrame, err := jsonframer.ToFrame(`{"items":[{"name":"foo","tags":["a"]}]}`, jsonframer.FramerOptions{
+			SelectorType: jsonframer.SelectorTypeJSONPath,
+			RootSelector: "$.items[*].tags",
+			Columns:      []jsonframer.ColumnSelector{{Selector: "$[*]", Alias: "tags"}},
+		})
+		require.Nil(t, err)
+		require.Equal(t, 1, gotFrame.Rows())
+		tags, _ := gotFrame.FieldByName("tags")
+		require.NotNil(t, tags)
+		value, _ := tags.ConcreteAt(0)
+		require.Equal(t, `["a"]`, value)
+	})
+}
+
+func TestColumnTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		column  jsonframer.ColumnSelector
+		want    any
+		wantErr string
+	}{
+		{name: "string", value: `"foo"`, column: jsonframer.ColumnSelector{Type: "string"}, want: "foo"},
+		{name: "string from number", value: `12.5`, column: jsonframer.ColumnSelector{Type: "string"}, want: "12.5"},
+		{name: "string from boolean", value: `true`, column: jsonframer.ColumnSelector{Type: "string"}, want: "true"},
+		{name: "string from null", value: `null`, column: jsonframer.ColumnSelector{Type: "string"}},
+		{name: "number", value: `3`, column: jsonframer.ColumnSelector{Type: "number"}, want: 3.0},
+		{name: "number from string", value: `"1.5"`, column: jsonframer.ColumnSelector{Type: "number"}, want: 1.5},
+		{name: "number from invalid string", value: `"abc"`, column: jsonframer.ColumnSelector{Type: "number"}},
+		{name: "number from boolean", value: `true`, column: jsonframer.ColumnSelector{Type: "number"}},
+		{name: "boolean", value: `false`, column: jsonframer.ColumnSelector{Type: "boolean"}, want: false},
+		{name: "boolean from string", value: `" TRUE "`, column: jsonframer.ColumnSelector{Type: "boolean"}, want: true},
+		{name: "boolean from number", value: `1`, column: jsonframer.ColumnSelector{Type: "boolean"}},
+		{name: "timestamp", value: `"2011-01-01T00:00:00Z"`, column: jsonframer.ColumnSelector{Type: "timestamp"}, want: time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp from year", value: `2011`, column: jsonframer.ColumnSelector{Type: "timestamp"}, want: time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp from invalid string", value: `"foo"`, column: jsonframer.ColumnSelector{Type: "timestamp"}},
+		{name: "timestamp with moment format", value: `"2023/01/02"`, column: jsonframer.ColumnSelector{Type: "timestamp", TimeFormat: "YYYY/MM/DD"}, want: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp with strftime format", value: `"2023/01/02"`, column: jsonframer.ColumnSelector{Type: "timestamp", TimeFormat: "%Y/%m/%d"}, want: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp with go layout", value: `"02 Jan 2023"`, column: jsonframer.ColumnSelector{Type: "timestamp", TimeFormat: "02 Jan 2006"}, want: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp number with format", value: `20230102`, column: jsonframer.ColumnSelector{Type: "timestamp", TimeFormat: "YYYYMMDD"}, want: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp not matching the format should throw error", value: `"2023-01-02"`, column: jsonframer.ColumnSelector{Type: "timestamp", TimeFormat: "YYYY/MM/DD"}, wantErr: `invalid time value "2023-01-02" for column "v". expected format "YYYY/MM/DD"`},
+		{name: "timestamp epoch", value: `1262304000000`, column: jsonframer.ColumnSelector{Type: "timestamp_epoch"}, want: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp epoch from string", value: `"1262304000000"`, column: jsonframer.ColumnSelector{Type: "timestamp_epoch"}, want: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp epoch from invalid string", value: `"abc"`, column: jsonframer.ColumnSelector{Type: "timestamp_epoch"}},
+		{name: "timestamp epoch seconds", value: `1262304000`, column: jsonframer.ColumnSelector{Type: "timestamp_epoch_s"}, want: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp epoch seconds from boolean", value: `true`, column: jsonframer.ColumnSelector{Type: "timestamp_epoch_s"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column := tt.column
+			column.Selector = "v"
+			gotFrame, err := jsonframer.ToFrame(`[{ "v" : `+tt.value+` }]`, jsonframer.FramerOptions{Columns: []jsonframer.ColumnSelector{column}})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			field, _ := gotFrame.FieldByName("v")
+			require.NotNil(t, field)
+			got, ok := field.ConcreteAt(0)
+			if tt.want == nil {
+				require.False(t, ok, got)
+				return
+			}
+			require.True(t, ok)
+			if want, isTime := tt.want.(time.Time); isTime {
+				require.True(t, want.Equal(got.(time.Time)), got)
+				return
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
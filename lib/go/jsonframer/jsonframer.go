@@ -4,23 +4,45 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/tidwall/gjson"
 	"github.com/xiatechs/jsonata-go"
 	"github.com/yesoreyeram/grafana-plugins/lib/go/gframer"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/macros"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/utils"
 )
 
 type FramerType string
 
 const (
-	FramerTypeGJSON   FramerType = "gjson"
+	FramerTypeGJSON FramerType = "gjson"
+	// FramerTypeSQLite3 queries the json using the cgo sqlite3 driver. Input without columns frames as an empty result
 	FramerTypeSQLite3 FramerType = "sqlite3"
+	// FramerTypeSQLite queries the json using the pure go sqlite engine. Doesn't require cgo
+	FramerTypeSQLite FramerType = "sqlite"
+)
+
+// SelectorType defines how the root selector and column selectors are evaluated
+type SelectorType string
+
+const (
+	// SelectorTypeAuto evaluates the root selector as gjson path and falls back to JSONata when the path doesn't exist. Column selectors are gjson paths
+	SelectorTypeAuto SelectorType = "auto"
+	// SelectorTypeGJSON evaluates the selectors as gjson paths
+	SelectorTypeGJSON SelectorType = "gjson"
+	// SelectorTypeJSONata evaluates the selectors as JSONata expressions
+	SelectorTypeJSONata SelectorType = "jsonata"
+	// SelectorTypeJSONPath evaluates the selectors as RFC 9535 JSONPath queries. Column selectors are evaluated against each row
+	SelectorTypeJSONPath SelectorType = "jsonpath"
 )
 
 type FramerOptions struct {
-	FramerType      FramerType // `gjson` | `sqlite3`
+	FramerType      FramerType   // `gjson` | `sqlite3` | `sqlite`
+	SelectorType    SelectorType // `auto` | `gjson` | `jsonata` | `jsonpath`. Defaults to `auto`
 	SQLite3Query    string
 	FrameName       string
 	RootSelector    string
@@ -50,58 +72,127 @@ func ToFrame(jsonString string, options FramerOptions) (frame *data.Frame, err e
 			return frame, err
 		}
 		return getFrameFromResponseString(outString, options)
+	case FramerTypeSQLite:
+		outString, err = QueryJSONUsingSQLite(outString, options.SQLite3Query, options.RootSelector, options.SelectorType)
+		if err != nil {
+			return frame, err
+		}
+		return getFrameFromResponseString(outString, options)
 	default:
-		outString, err := GetRootData(jsonString, options.RootSelector)
+		outString, err := getRootData(jsonString, options.RootSelector, options.SelectorType)
 		if err != nil {
 			return frame, err
 		}
-		outString, err = getColumnValuesFromResponseString(outString, options.Columns)
+		outString, err = getColumnValuesFromResponseString(outString, options.Columns, options.SelectorType)
 		if err != nil {
 			return frame, err
 		}
+		options.Columns = convertedColumns(options.Columns)
 		return getFrameFromResponseString(outString, options)
 	}
 }
 
+// GetRootData returns the data of the root selector. Root selector is evaluated as gjson path and falls back to JSONata when the path doesn't exist
 func GetRootData(jsonString string, rootSelector string) (string, error) {
-	if rootSelector != "" {
-		r := gjson.Get(string(jsonString), rootSelector)
+	return getRootData(jsonString, rootSelector, SelectorTypeAuto)
+}
+
+func getRootData(jsonString string, rootSelector string, selectorType SelectorType) (string, error) {
+	if rootSelector == "" {
+		return jsonString, nil
+	}
+	switch selectorType {
+	case "", SelectorTypeAuto:
+		r := gjson.Get(jsonString, rootSelector)
 		if r.Exists() {
 			return r.String(), nil
 		}
-		if e := jsonata.MustCompile(rootSelector); e != nil {
-			var data interface{}
-			err := json.Unmarshal([]byte(jsonString), &data)
-			if err != nil {
-				return "", err
-			}
-			if res, err := e.Eval(data); err == nil {
-				if r, err := json.Marshal(res); err == nil {
-					return string(r), nil
-				}
-			}
+		e, err := jsonata.Compile(rootSelector)
+		if err != nil {
+			return "", fmt.Errorf("invalid root selector %q. root selector is neither an existing gjson path nor a valid jsonata expression. %w", rootSelector, err)
+		}
+		if out, err := evalJSONata(e, jsonString); err == nil {
+			return out, nil
 		}
 		return "", errors.New("root object doesn't exist in the response. Root selector:" + rootSelector)
-
+	case SelectorTypeGJSON:
+		r := gjson.Get(jsonString, rootSelector)
+		if !r.Exists() {
+			return "", errors.New("root object doesn't exist in the response. Root selector:" + rootSelector)
+		}
+		return r.String(), nil
+	case SelectorTypeJSONata:
+		e, err := jsonata.Compile(rootSelector)
+		if err != nil {
+			return "", fmt.Errorf("invalid jsonata root selector %q. %w", rootSelector, err)
+		}
+		out, err := evalJSONata(e, jsonString)
+		if err != nil {
+			return "", fmt.Errorf("error applying jsonata root selector %q. %w", rootSelector, err)
+		}
+		return out, nil
+	case SelectorTypeJSONPath:
+		path, err := compileJSONPath(rootSelector)
+		if err != nil {
+			return "", err
+		}
+		var data interface{}
+		if err := json.Unmarshal([]byte(jsonString), &data); err != nil {
+			return "", err
+		}
+		nodes := path.selectValues(data)
+		var out interface{} = nodes
+		if path.singular() {
+			if len(nodes) == 0 {
+				return "", errors.New("root object doesn't exist in the response. Root selector:" + rootSelector)
+			}
+			out = nodes[0]
+		}
+		r, err := json.Marshal(out)
+		if err != nil {
+			return "", err
+		}
+		return string(r), nil
+	default:
+		return "", fmt.Errorf("invalid selector type %q", selectorType)
 	}
-	return jsonString, nil
+}
 
+func evalJSONata(e *jsonata.Expr, jsonString string) (string, error) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(jsonString), &data); err != nil {
+		return "", err
+	}
+	res, err := e.Eval(data)
+	if err != nil {
+		return "", err
+	}
+	r, err := json.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+	return string(r), nil
 }
 
-func getColumnValuesFromResponseString(responseString string, columns []ColumnSelector) (string, error) {
+func getColumnValuesFromResponseString(responseString string, columns []ColumnSelector, selectorType SelectorType) (string, error) {
+	if len(columns) > 0 && selectorType == SelectorTypeJSONata {
+		return getColumnValuesUsingJSONata(responseString, columns)
+	}
+	if len(columns) > 0 && selectorType == SelectorTypeJSONPath {
+		return getColumnValuesUsingJSONPath(responseString, columns)
+	}
 	if len(columns) > 0 {
 		outString := responseString
 		result := gjson.Parse(outString)
 		out := []map[string]interface{}{}
+		var err error
 		if result.IsArray() {
 			result.ForEach(func(key, value gjson.Result) bool {
 				oi := map[string]interface{}{}
 				for _, col := range columns {
-					name := col.Alias
-					if name == "" {
-						name = col.Selector
+					if oi[columnName(col)], err = convertFieldValueType(gjson.Get(value.Raw, col.Selector).Value(), col); err != nil {
+						return false
 					}
-					oi[name] = convertFieldValueType(gjson.Get(value.Raw, col.Selector).Value(), col)
 				}
 				out = append(out, oi)
 				return true
@@ -110,14 +201,15 @@ func getColumnValuesFromResponseString(responseString string, columns []ColumnSe
 		if !result.IsArray() && result.IsObject() {
 			oi := map[string]interface{}{}
 			for _, col := range columns {
-				name := col.Alias
-				if name == "" {
-					name = col.Selector
+				if oi[columnName(col)], err = convertFieldValueType(gjson.Get(result.Raw, col.Selector).Value(), col); err != nil {
+					break
 				}
-				oi[name] = convertFieldValueType(gjson.Get(result.Raw, col.Selector).Value(), col)
 			}
 			out = append(out, oi)
 		}
+		if err != nil {
+			return "", err
+		}
 		a, err := json.Marshal(out)
 		if err != nil {
 			return "", err
@@ -127,6 +219,95 @@ func getColumnValuesFromResponseString(responseString string, columns []ColumnSe
 	return responseString, nil
 }
 
+// getColumnValuesUsingJSONata evaluates the column selectors as JSONata expressions against each row. Missing values are set as null
+func getColumnValuesUsingJSONata(responseString string, columns []ColumnSelector) (string, error) {
+	exprs := make([]*jsonata.Expr, len(columns))
+	for i, col := range columns {
+		e, err := jsonata.Compile(col.Selector)
+		if err != nil {
+			return "", fmt.Errorf("invalid jsonata selector %q for column %q. %w", col.Selector, columnName(col), err)
+		}
+		exprs[i] = e
+	}
+	return getColumnValues(responseString, columns, func(i int, row interface{}) interface{} {
+		value, err := exprs[i].Eval(row)
+		if err != nil {
+			return nil
+		}
+		return value
+	})
+}
+
+// getColumnValuesUsingJSONPath evaluates the column selectors as JSONPath queries against each row. Selectors without the
+// root identifier such as `name` or `['first name']` are relative to the row. Non singular selectors always return array
+func getColumnValuesUsingJSONPath(responseString string, columns []ColumnSelector) (string, error) {
+	paths := make([]*jsonPath, len(columns))
+	for i, col := range columns {
+		selector := col.Selector
+		if !strings.HasPrefix(selector, "$") {
+			if strings.HasPrefix(selector, "[") {
+				selector = "$" + selector
+			} else {
+				selector = "$." + selector
+			}
+		}
+		path, err := compileJSONPath(selector)
+		if err != nil {
+			return "", fmt.Errorf("invalid jsonpath selector for column %q. %w", columnName(col), err)
+		}
+		paths[i] = path
+	}
+	return getColumnValues(responseString, columns, func(i int, row interface{}) interface{} {
+		nodes := paths[i].selectValues(row)
+		if !paths[i].singular() {
+			return nodes
+		}
+		if len(nodes) == 0 {
+			return nil
+		}
+		return nodes[0]
+	})
+}
+
+// getColumnValues builds the rows of the column values. Object response is considered as single row
+func getColumnValues(responseString string, columns []ColumnSelector, value func(i int, row interface{}) interface{}) (string, error) {
+	var input interface{}
+	if err := json.Unmarshal([]byte(responseString), &input); err != nil {
+		return "", err
+	}
+	rows := []interface{}{}
+	switch x := input.(type) {
+	case []interface{}:
+		rows = x
+	case map[string]interface{}:
+		rows = append(rows, x)
+	}
+	out := []map[string]interface{}{}
+	for _, row := range rows {
+		oi := map[string]interface{}{}
+		for i, col := range columns {
+			v, err := convertFieldValueType(value(i, row), col)
+			if err != nil {
+				return "", err
+			}
+			oi[columnName(col)] = v
+		}
+		out = append(out, oi)
+	}
+	a, err := json.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+	return string(a), nil
+}
+
+func columnName(col ColumnSelector) string {
+	if col.Alias != "" {
+		return col.Alias
+	}
+	return col.Selector
+}
+
 func getFrameFromResponseString(responseString string, options FramerOptions) (frame *data.Frame, err error) {
 	var out interface{}
 	err = json.Unmarshal([]byte(responseString), &out)
@@ -158,6 +339,120 @@ func getFrameFromResponseString(responseString string, options FramerOptions) (f
 	})
 }
 
-func convertFieldValueType(input interface{}, _ ColumnSelector) interface{} {
-	return input
+// convertFieldValueType converts the selected value to the column type the same way anyframer.SliceToFrame does.
+// Values that can't be converted are returned as null. Timestamps not matching the declared time format returns error
+func convertFieldValueType(input interface{}, col ColumnSelector) (interface{}, error) {
+	switch col.Type {
+	case "string":
+		switch v := input.(type) {
+		case string:
+			return v, nil
+		case float64, int, int64, bool:
+			return fmt.Sprintf("%v", v), nil
+		}
+		return nil, nil
+	case "number":
+		switch v := input.(type) {
+		case string:
+			if item, err := strconv.ParseFloat(v, 64); err == nil {
+				return item, nil
+			}
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		}
+		return nil, nil
+	case "boolean":
+		switch v := input.(type) {
+		case bool:
+			return v, nil
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "true":
+				return true, nil
+			case "false":
+				return false, nil
+			}
+		}
+		return nil, nil
+	case "timestamp":
+		value := ""
+		switch v := input.(type) {
+		case float64:
+			if col.TimeFormat == "" || col.TimeFormat == "auto" {
+				if t, err := time.Parse("2006", fmt.Sprintf("%v", v)); err == nil {
+					return t, nil
+				}
+				return nil, nil
+			}
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		case string:
+			value = v
+		}
+		if value == "" {
+			return nil, nil
+		}
+		t, err := getTimeFromFormat(value, col.TimeFormat)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time value %q for column %q. %w", value, columnName(col), err)
+		}
+		if t == nil {
+			return nil, nil
+		}
+		return *t, nil
+	case "timestamp_epoch", "timestamp_epoch_s":
+		var epoch int64
+		switch v := input.(type) {
+		case string:
+			item, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, nil
+			}
+			epoch = item
+		case float64:
+			epoch = int64(v)
+		default:
+			return nil, nil
+		}
+		if col.Type == "timestamp_epoch_s" {
+			return time.Unix(epoch, 0), nil
+		}
+		return time.UnixMilli(epoch), nil
+	}
+	return input, nil
+}
+
+// getTimeFromFormat parses the input strictly with the declared time format. Moment style (YYYY-MM-DD), strftime style (%Y-%m-%d)
+// and go layouts are supported. When no format is declared or the format is auto, the known layouts are tried instead
+func getTimeFromFormat(input string, timeFormat string) (*time.Time, error) {
+	if timeFormat == "" || timeFormat == "auto" {
+		return utils.GetTimeFromString(input, ""), nil
+	}
+	layouts := []string{macros.ToGoTimeLayout(timeFormat)}
+	if layouts[0] != timeFormat {
+		layouts = append(layouts, timeFormat)
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, input); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("expected format %q", timeFormat)
+}
+
+// convertedColumns returns the columns used to frame the converted values. Converted timestamps are marshaled as RFC3339 strings
+func convertedColumns(columns []ColumnSelector) []ColumnSelector {
+	out := make([]ColumnSelector, len(columns))
+	for i, col := range columns {
+		out[i] = col
+		switch col.Type {
+		case "timestamp", "timestamp_epoch", "timestamp_epoch_s":
+			out[i].Type = "timestamp"
+			out[i].TimeFormat = time.RFC3339Nano
+		}
+	}
+	return out
 }
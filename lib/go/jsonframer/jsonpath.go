@@ -0,0 +1,1072 @@
+package jsonframer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// RFC 9535 JSONPath implementation. Queries are compiled once and evaluated against the documents decoded with encoding/json.
+// Object members are visited in the sorted key order as the decoded objects don't retain the member order
+
+const jsonPathMaxInt = 1<<53 - 1
+
+type jsonPath struct {
+	relative bool
+	segments []jsonPathSegment
+}
+
+type jsonPathSegment struct {
+	descendant bool
+	selectors  []jsonPathSelector
+}
+
+type jsonPathSelectorKind int
+
+const (
+	jsonPathSelectorName jsonPathSelectorKind = iota
+	jsonPathSelectorWildcard
+	jsonPathSelectorIndex
+	jsonPathSelectorSlice
+	jsonPathSelectorFilter
+)
+
+type jsonPathSelector struct {
+	kind   jsonPathSelectorKind
+	name   string
+	index  int64
+	start  *int64
+	end    *int64
+	step   *int64
+	filter jsonPathExpr
+}
+
+// QueryJSONPath returns the values of the nodes selected by the RFC 9535 JSONPath query in the decoded json document
+func QueryJSONPath(document any, query string) ([]any, error) {
+	path, err := compileJSONPath(query)
+	if err != nil {
+		return nil, err
+	}
+	return path.selectValues(document), nil
+}
+
+// compileJSONPath compiles the RFC 9535 JSONPath query
+func compileJSONPath(query string) (*jsonPath, error) {
+	p := &jsonPathParser{input: query}
+	if !strings.HasPrefix(query, "$") {
+		return nil, fmt.Errorf("invalid jsonpath %q. query must start with $", query)
+	}
+	path, err := p.parseQuery()
+	if err != nil {
+		return nil, fmt.Errorf("invalid jsonpath %q. %w", query, err)
+	}
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("invalid jsonpath %q. unexpected character at position %d", query, p.pos)
+	}
+	return path, nil
+}
+
+// selectValues returns the values of the nodes selected by the query in the document
+func (path *jsonPath) selectValues(document any) []any {
+	return path.eval(document, document)
+}
+
+func (path *jsonPath) eval(root any, current any) []any {
+	nodes := []any{current}
+	if !path.relative {
+		nodes = []any{root}
+	}
+	for _, segment := range path.segments {
+		out := []any{}
+		for _, node := range nodes {
+			if segment.descendant {
+				out = segment.selectDescendants(root, node, out)
+				continue
+			}
+			out = segment.selectChildren(root, node, out)
+		}
+		nodes = out
+	}
+	return nodes
+}
+
+// singular returns true when the query selects at most one node
+func (path *jsonPath) singular() bool {
+	for _, segment := range path.segments {
+		if segment.descendant || len(segment.selectors) != 1 {
+			return false
+		}
+		if k := segment.selectors[0].kind; k != jsonPathSelectorName && k != jsonPathSelectorIndex {
+			return false
+		}
+	}
+	return true
+}
+
+func (segment jsonPathSegment) selectChildren(root any, node any, out []any) []any {
+	for _, selector := range segment.selectors {
+		out = selector.apply(root, node, out)
+	}
+	return out
+}
+
+func (segment jsonPathSegment) selectDescendants(root any, node any, out []any) []any {
+	out = segment.selectChildren(root, node, out)
+	for _, child := range jsonPathChildren(node) {
+		out = segment.selectDescendants(root, child, out)
+	}
+	return out
+}
+
+func jsonPathChildren(node any) []any {
+	switch x := node.(type) {
+	case []any:
+		return x
+	case map[string]any:
+		out := make([]any, 0, len(x))
+		for _, key := range jsonPathSortedKeys(x) {
+			out = append(out, x[key])
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+func jsonPathSortedKeys(input map[string]any) []string {
+	keys := make([]string, 0, len(input))
+	for key := range input {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (selector jsonPathSelector) apply(root any, node any, out []any) []any {
+	switch selector.kind {
+	case jsonPathSelectorName:
+		if o, ok := node.(map[string]any); ok {
+			if v, ok := o[selector.name]; ok {
+				out = append(out, v)
+			}
+		}
+	case jsonPathSelectorWildcard:
+		out = append(out, jsonPathChildren(node)...)
+	case jsonPathSelectorIndex:
+		if a, ok := node.([]any); ok {
+			i := selector.index
+			if i < 0 {
+				i += int64(len(a))
+			}
+			if i >= 0 && i < int64(len(a)) {
+				out = append(out, a[i])
+			}
+		}
+	case jsonPathSelectorSlice:
+		if a, ok := node.([]any); ok {
+			out = selector.slice(a, out)
+		}
+	case jsonPathSelectorFilter:
+		for _, child := range jsonPathChildren(node) {
+			if selector.filter.test(root, child) {
+				out = append(out, child)
+			}
+		}
+	}
+	return out
+}
+
+func (selector jsonPathSelector) slice(a []any, out []any) []any {
+	length := int64(len(a))
+	step := int64(1)
+	if selector.step != nil {
+		step = *selector.step
+	}
+	if step == 0 {
+		return out
+	}
+	normalize := func(i int64) int64 {
+		if i >= 0 {
+			return i
+		}
+		return length + i
+	}
+	clamp := func(i, lower, upper int64) int64 {
+		return min(max(i, lower), upper)
+	}
+	if step > 0 {
+		start, end := int64(0), length
+		if selector.start != nil {
+			start = normalize(*selector.start)
+		}
+		if selector.end != nil {
+			end = normalize(*selector.end)
+		}
+		for i := clamp(start, 0, length); i < clamp(end, 0, length); i += step {
+			out = append(out, a[i])
+		}
+		return out
+	}
+	start, end := length-1, -length-1
+	if selector.start != nil {
+		start = normalize(*selector.start)
+	}
+	if selector.end != nil {
+		end = normalize(*selector.end)
+	}
+	for i := clamp(start, -1, length-1); clamp(end, -1, length-1) < i; i += step {
+		out = append(out, a[i])
+	}
+	return out
+}
+
+//#region filter expressions
+
+type jsonPathType int
+
+const (
+	jsonPathValueType jsonPathType = iota
+	jsonPathLogicalType
+	jsonPathNodesType
+)
+
+// jsonPathExpr is the node of the filter expression
+type jsonPathExpr interface {
+	test(root any, current any) bool
+}
+
+type jsonPathLiteral struct{ value any }
+
+type jsonPathQueryExpr struct{ path *jsonPath }
+
+type jsonPathNotExpr struct{ expr jsonPathExpr }
+
+type jsonPathAndExpr struct{ left, right jsonPathExpr }
+
+type jsonPathOrExpr struct{ left, right jsonPathExpr }
+
+type jsonPathParenExpr struct{ expr jsonPathExpr }
+
+type jsonPathComparisonExpr struct {
+	op          string
+	left, right jsonPathExpr
+}
+
+type jsonPathFunctionExpr struct {
+	name string
+	args []jsonPathExpr
+	def  jsonPathFunction
+}
+
+type jsonPathFunction struct {
+	params []jsonPathType
+	result jsonPathType
+	call   func(args []any) any
+}
+
+// jsonPathNothing is the absence of value such as the result of the singular query selecting no node
+type jsonPathNothing struct{}
+
+var jsonPathFunctions = map[string]jsonPathFunction{
+	"length": {params: []jsonPathType{jsonPathValueType}, result: jsonPathValueType, call: jsonPathLength},
+	"count":  {params: []jsonPathType{jsonPathNodesType}, result: jsonPathValueType, call: jsonPathCount},
+	"match":  {params: []jsonPathType{jsonPathValueType, jsonPathValueType}, result: jsonPathLogicalType, call: jsonPathMatch(true)},
+	"search": {params: []jsonPathType{jsonPathValueType, jsonPathValueType}, result: jsonPathLogicalType, call: jsonPathMatch(false)},
+	"value":  {params: []jsonPathType{jsonPathNodesType}, result: jsonPathValueType, call: jsonPathValue},
+}
+
+func (e jsonPathLiteral) test(_ any, _ any) bool { return false }
+
+func (e jsonPathQueryExpr) test(root any, current any) bool {
+	return len(e.path.eval(root, current)) > 0
+}
+
+func (e jsonPathNotExpr) test(root any, current any) bool { return !e.expr.test(root, current) }
+
+func (e jsonPathAndExpr) test(root any, current any) bool {
+	return e.left.test(root, current) && e.right.test(root, current)
+}
+
+func (e jsonPathOrExpr) test(root any, current any) bool {
+	return e.left.test(root, current) || e.right.test(root, current)
+}
+
+func (e jsonPathParenExpr) test(root any, current any) bool { return e.expr.test(root, current) }
+
+func (e jsonPathComparisonExpr) test(root any, current any) bool {
+	left, right := jsonPathComparable(e.left, root, current), jsonPathComparable(e.right, root, current)
+	switch e.op {
+	case "==":
+		return jsonPathEqual(left, right)
+	case "!=":
+		return !jsonPathEqual(left, right)
+	case "<":
+		return jsonPathLess(left, right)
+	case "<=":
+		return jsonPathLess(left, right) || jsonPathEqual(left, right)
+	case ">":
+		return jsonPathLess(right, left)
+	case ">=":
+		return jsonPathLess(right, left) || jsonPathEqual(left, right)
+	}
+	return false
+}
+
+func (e jsonPathFunctionExpr) test(root any, current any) bool {
+	result := e.eval(root, current)
+	switch r := result.(type) {
+	case bool:
+		return r
+	case []any:
+		return len(r) > 0
+	}
+	return false
+}
+
+func (e jsonPathFunctionExpr) eval(root any, current any) any {
+	args := make([]any, len(e.args))
+	for i, arg := range e.args {
+		switch e.def.params[i] {
+		case jsonPathNodesType:
+			args[i] = arg.(jsonPathQueryExpr).path.eval(root, current)
+		case jsonPathLogicalType:
+			args[i] = arg.test(root, current)
+		default:
+			args[i] = jsonPathComparable(arg, root, current)
+		}
+	}
+	return e.def.call(args)
+}
+
+// jsonPathComparable returns the value of the literal, singular query or function
+func jsonPathComparable(e jsonPathExpr, root any, current any) any {
+	switch x := e.(type) {
+	case jsonPathLiteral:
+		return x.value
+	case jsonPathQueryExpr:
+		nodes := x.path.eval(root, current)
+		if len(nodes) == 1 {
+			return nodes[0]
+		}
+		return jsonPathNothing{}
+	case jsonPathFunctionExpr:
+		return x.eval(root, current)
+	}
+	return jsonPathNothing{}
+}
+
+func jsonPathEqual(left any, right any) bool {
+	switch l := left.(type) {
+	case jsonPathNothing:
+		_, ok := right.(jsonPathNothing)
+		return ok
+	case nil:
+		return right == nil
+	case float64:
+		r, ok := right.(float64)
+		return ok && l == r
+	case string:
+		r, ok := right.(string)
+		return ok && l == r
+	case bool:
+		r, ok := right.(bool)
+		return ok && l == r
+	case []any:
+		r, ok := right.([]any)
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for i := range l {
+			if !jsonPathEqual(l[i], r[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		r, ok := right.(map[string]any)
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for k, v := range l {
+			rv, ok := r[k]
+			if !ok || !jsonPathEqual(v, rv) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func jsonPathLess(left any, right any) bool {
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		return ok && l < r
+	case string:
+		r, ok := right.(string)
+		return ok && l < r
+	}
+	return false
+}
+
+func jsonPathLength(args []any) any {
+	switch x := args[0].(type) {
+	case string:
+		return float64(utf8.RuneCountInString(x))
+	case []any:
+		return float64(len(x))
+	case map[string]any:
+		return float64(len(x))
+	}
+	return jsonPathNothing{}
+}
+
+func jsonPathCount(args []any) any {
+	return float64(len(args[0].([]any)))
+}
+
+func jsonPathValue(args []any) any {
+	if nodes := args[0].([]any); len(nodes) == 1 {
+		return nodes[0]
+	}
+	return jsonPathNothing{}
+}
+
+func jsonPathMatch(full bool) func(args []any) any {
+	return func(args []any) any {
+		input, ok := args[0].(string)
+		if !ok {
+			return false
+		}
+		pattern, ok := args[1].(string)
+		if !ok {
+			return false
+		}
+		re, err := compileIRegexp(pattern, full)
+		if err != nil {
+			return false
+		}
+		return re.MatchString(input)
+	}
+}
+
+// jsonPathMatchRegexp returns the match / search function of the pattern compiled once. Invalid pattern matches nothing
+func jsonPathMatchRegexp(re *regexp.Regexp) func(args []any) any {
+	return func(args []any) any {
+		input, ok := args[0].(string)
+		return ok && re != nil && re.MatchString(input)
+	}
+}
+
+// compileIRegexp converts the I-Regexp (RFC 9485) into go regular expression. Dot doesn't match the line terminators
+// and ^ / $ are not anchors in I-Regexp
+func compileIRegexp(pattern string, full bool) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			sb.WriteByte(c)
+			sb.WriteByte(pattern[i+1])
+			i++
+		case c == '[' && !inClass:
+			inClass = true
+			sb.WriteByte(c)
+		case c == ']' && inClass:
+			inClass = false
+			sb.WriteByte(c)
+		case c == '.' && !inClass:
+			sb.WriteString(`[^\n\r]`)
+		case (c == '^' || c == '$') && !inClass:
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	if full {
+		return regexp.Compile(`\A(?:` + sb.String() + `)\z`)
+	}
+	return regexp.Compile(sb.String())
+}
+
+//#endregion
+
+//#region parser
+
+type jsonPathParser struct {
+	input string
+	pos   int
+}
+
+func (p *jsonPathParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%s at position %d", fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *jsonPathParser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+func (p *jsonPathParser) skipSpaces() {
+	for p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *jsonPathParser) consume(s string) bool {
+	if strings.HasPrefix(p.input[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathParser) parseQuery() (*jsonPath, error) {
+	path := &jsonPath{}
+	switch p.peek() {
+	case '$':
+	case '@':
+		path.relative = true
+	default:
+		return nil, p.errorf("expected $")
+	}
+	p.pos++
+	for {
+		start := p.pos
+		p.skipSpaces()
+		if c := p.peek(); c != '.' && c != '[' {
+			p.pos = start
+			return path, nil
+		}
+		segment, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		path.segments = append(path.segments, segment)
+	}
+}
+
+func (p *jsonPathParser) parseSegment() (jsonPathSegment, error) {
+	segment := jsonPathSegment{}
+	if p.consume("..") {
+		segment.descendant = true
+		if p.peek() == '[' {
+			selectors, err := p.parseBracketedSelection()
+			segment.selectors = selectors
+			return segment, err
+		}
+	} else if p.consume(".") {
+	} else {
+		selectors, err := p.parseBracketedSelection()
+		segment.selectors = selectors
+		return segment, err
+	}
+	if p.consume("*") {
+		segment.selectors = []jsonPathSelector{{kind: jsonPathSelectorWildcard}}
+		return segment, nil
+	}
+	name, err := p.parseMemberNameShorthand()
+	if err != nil {
+		return segment, err
+	}
+	segment.selectors = []jsonPathSelector{{kind: jsonPathSelectorName, name: name}}
+	return segment, nil
+}
+
+func isJSONPathNameFirst(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' || (r >= 0x80 && r <= 0xD7FF) || (r >= 0xE000 && r <= 0x10FFFF)
+}
+
+func (p *jsonPathParser) parseMemberNameShorthand() (string, error) {
+	start := p.pos
+	for p.pos < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if r == utf8.RuneError && size <= 1 {
+			return "", p.errorf("invalid character")
+		}
+		if !isJSONPathNameFirst(r) && !(p.pos > start && r >= '0' && r <= '9') {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return "", p.errorf("expected member name")
+	}
+	return p.input[start:p.pos], nil
+}
+
+func (p *jsonPathParser) parseBracketedSelection() ([]jsonPathSelector, error) {
+	if !p.consume("[") {
+		return nil, p.errorf("expected [")
+	}
+	selectors := []jsonPathSelector{}
+	for {
+		p.skipSpaces()
+		selector, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+		p.skipSpaces()
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or ]")
+		}
+	}
+}
+
+func (p *jsonPathParser) parseSelector() (jsonPathSelector, error) {
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		name, err := p.parseStringLiteral()
+		return jsonPathSelector{kind: jsonPathSelectorName, name: name}, err
+	case c == '*':
+		p.pos++
+		return jsonPathSelector{kind: jsonPathSelectorWildcard}, nil
+	case c == '?':
+		p.pos++
+		p.skipSpaces()
+		expr, err := p.parseLogicalOr()
+		if err != nil {
+			return jsonPathSelector{}, err
+		}
+		return jsonPathSelector{kind: jsonPathSelectorFilter, filter: expr}, nil
+	default:
+		return p.parseIndexOrSlice()
+	}
+}
+
+func (p *jsonPathParser) parseIndexOrSlice() (jsonPathSelector, error) {
+	selector := jsonPathSelector{kind: jsonPathSelectorIndex}
+	start, err := p.parseOptionalInt()
+	if err != nil {
+		return selector, err
+	}
+	p.skipSpaces()
+	if !p.consume(":") {
+		if start == nil {
+			return selector, p.errorf("expected selector")
+		}
+		selector.index = *start
+		return selector, nil
+	}
+	selector = jsonPathSelector{kind: jsonPathSelectorSlice, start: start}
+	p.skipSpaces()
+	if selector.end, err = p.parseOptionalInt(); err != nil {
+		return selector, err
+	}
+	p.skipSpaces()
+	if p.consume(":") {
+		p.skipSpaces()
+		if selector.step, err = p.parseOptionalInt(); err != nil {
+			return selector, err
+		}
+	}
+	return selector, nil
+}
+
+func (p *jsonPathParser) parseOptionalInt() (*int64, error) {
+	c := p.peek()
+	if c != '-' && (c < '0' || c > '9') {
+		return nil, nil
+	}
+	start := p.pos
+	p.consume("-")
+	digits := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+	text := p.input[start:p.pos]
+	if p.pos == digits || (p.input[digits] == '0' && (p.pos-digits > 1 || digits > start)) {
+		return nil, p.errorf("invalid integer %q", text)
+	}
+	v, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || v > jsonPathMaxInt || v < -jsonPathMaxInt {
+		return nil, p.errorf("integer %q out of range", text)
+	}
+	return &v, nil
+}
+
+func (p *jsonPathParser) parseStringLiteral() (string, error) {
+	quote := p.input[p.pos]
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		case c < 0x20:
+			return "", p.errorf("invalid control character in string")
+		case c == '\\':
+			p.pos++
+			r, err := p.parseEscape(quote)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteRune(r)
+		default:
+			r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+			if r == utf8.RuneError && size <= 1 {
+				return "", p.errorf("invalid character in string")
+			}
+			sb.WriteString(p.input[p.pos : p.pos+size])
+			p.pos += size
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *jsonPathParser) parseEscape(quote byte) (rune, error) {
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case '/':
+		return '/', nil
+	case '\\':
+		return '\\', nil
+	case quote:
+		return rune(quote), nil
+	case 'u':
+		r, err := p.parseHex4()
+		if err != nil {
+			return 0, err
+		}
+		if r >= 0xDC00 && r <= 0xDFFF {
+			return 0, p.errorf("invalid low surrogate")
+		}
+		if r >= 0xD800 && r <= 0xDBFF {
+			if !p.consume(`\u`) {
+				return 0, p.errorf("expected low surrogate")
+			}
+			low, err := p.parseHex4()
+			if err != nil {
+				return 0, err
+			}
+			if low < 0xDC00 || low > 0xDFFF {
+				return 0, p.errorf("invalid low surrogate")
+			}
+			return (r-0xD800)<<10 + (low - 0xDC00) + 0x10000, nil
+		}
+		return r, nil
+	}
+	return 0, p.errorf("invalid escape")
+}
+
+func (p *jsonPathParser) parseHex4() (rune, error) {
+	if p.pos+4 > len(p.input) {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	v, err := strconv.ParseUint(p.input[p.pos:p.pos+4], 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	p.pos += 4
+	return rune(v), nil
+}
+
+func (p *jsonPathParser) parseLogicalOr() (jsonPathExpr, error) {
+	left, err := p.parseLogicalAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		start := p.pos
+		p.skipSpaces()
+		if !p.consume("||") {
+			p.pos = start
+			return left, nil
+		}
+		p.skipSpaces()
+		right, err := p.parseLogicalAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = jsonPathOrExpr{left: left, right: right}
+	}
+}
+
+func (p *jsonPathParser) parseLogicalAnd() (jsonPathExpr, error) {
+	left, err := p.parseLogicalBasic()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		start := p.pos
+		p.skipSpaces()
+		if !p.consume("&&") {
+			p.pos = start
+			return left, nil
+		}
+		p.skipSpaces()
+		right, err := p.parseLogicalBasic()
+		if err != nil {
+			return nil, err
+		}
+		left = jsonPathAndExpr{left: left, right: right}
+	}
+}
+
+func (p *jsonPathParser) parseLogicalBasic() (jsonPathExpr, error) {
+	expr, err := p.parseBasic()
+	if err != nil {
+		return nil, err
+	}
+	return expr, p.checkLogical(expr)
+}
+
+// parseBasic parses the paren, negation, comparison or test expression. Bare literals are returned as is to support function arguments
+func (p *jsonPathParser) parseBasic() (jsonPathExpr, error) {
+	if p.consume("!") {
+		p.skipSpaces()
+		if p.peek() == '(' {
+			expr, err := p.parseParen()
+			return jsonPathNotExpr{expr: expr}, err
+		}
+		expr, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := expr.(jsonPathLiteral); ok {
+			return nil, p.errorf("literal can't be negated")
+		}
+		return jsonPathNotExpr{expr: expr}, p.checkLogical(expr)
+	}
+	if p.peek() == '(' {
+		return p.parseParen()
+	}
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	start := p.pos
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if !p.consume(op) {
+			continue
+		}
+		p.skipSpaces()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.checkComparable(left); err != nil {
+			return nil, err
+		}
+		if err := p.checkComparable(right); err != nil {
+			return nil, err
+		}
+		return jsonPathComparisonExpr{op: op, left: left, right: right}, nil
+	}
+	p.pos = start
+	return left, nil
+}
+
+func (p *jsonPathParser) parseParen() (jsonPathExpr, error) {
+	p.consume("(")
+	p.skipSpaces()
+	expr, err := p.parseLogicalOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if !p.consume(")") {
+		return nil, p.errorf("expected )")
+	}
+	return jsonPathParenExpr{expr: expr}, nil
+}
+
+// parsePrimary parses the literal, filter query or function expression
+func (p *jsonPathParser) parsePrimary() (jsonPathExpr, error) {
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		path, err := p.parseQuery()
+		if err != nil {
+			return nil, err
+		}
+		return jsonPathQueryExpr{path: path}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseStringLiteral()
+		return jsonPathLiteral{value: s}, err
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for p.pos < len(p.input) {
+			c := p.input[p.pos]
+			if !((c >= 'a' && c <= 'z') || c == '_' || (c >= '0' && c <= '9')) {
+				break
+			}
+			p.pos++
+		}
+		name := p.input[start:p.pos]
+		if p.peek() == '(' {
+			return p.parseFunction(name)
+		}
+		switch name {
+		case "true":
+			return jsonPathLiteral{value: true}, nil
+		case "false":
+			return jsonPathLiteral{value: false}, nil
+		case "null":
+			return jsonPathLiteral{value: nil}, nil
+		}
+		p.pos = start
+		return nil, p.errorf("unexpected %q", name)
+	}
+	return nil, p.errorf("expected filter expression")
+}
+
+var jsonPathNumber = regexp.MustCompile(`^(-?(0|[1-9][0-9]*))(\.[0-9]+)?([eE][-+]?[0-9]+)?`)
+
+func (p *jsonPathParser) parseNumber() (jsonPathExpr, error) {
+	text := jsonPathNumber.FindString(p.input[p.pos:])
+	if text == "" {
+		return nil, p.errorf("invalid number")
+	}
+	p.pos += len(text)
+	if c := p.peek(); (c >= '0' && c <= '9') || c == '.' {
+		return nil, p.errorf("invalid number")
+	}
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsInf(v, 0) {
+		return nil, p.errorf("invalid number %q", text)
+	}
+	return jsonPathLiteral{value: v}, nil
+}
+
+func (p *jsonPathParser) parseFunction(name string) (jsonPathExpr, error) {
+	def, ok := jsonPathFunctions[name]
+	if !ok {
+		return nil, p.errorf("unknown function %q", name)
+	}
+	p.consume("(")
+	fn := jsonPathFunctionExpr{name: name, def: def}
+	p.skipSpaces()
+	if !p.consume(")") {
+		for {
+			p.skipSpaces()
+			arg, err := p.parseFunctionArgument()
+			if err != nil {
+				return nil, err
+			}
+			fn.args = append(fn.args, arg)
+			p.skipSpaces()
+			if p.consume(")") {
+				break
+			}
+			if !p.consume(",") {
+				return nil, p.errorf("expected , or )")
+			}
+		}
+	}
+	if len(fn.args) != len(def.params) {
+		return nil, p.errorf("function %s expects %d argument(s)", name, len(def.params))
+	}
+	for i, arg := range fn.args {
+		if err := p.checkArgument(arg, def.params[i]); err != nil {
+			return nil, fmt.Errorf("invalid argument %d of function %s. %w", i+1, name, err)
+		}
+	}
+	// literal patterns of match / search are compiled once instead of for every node
+	if name == "match" || name == "search" {
+		if lit, ok := fn.args[1].(jsonPathLiteral); ok {
+			if pattern, ok := lit.value.(string); ok {
+				re, _ := compileIRegexp(pattern, name == "match")
+				fn.def.call = jsonPathMatchRegexp(re)
+			}
+		}
+	}
+	return fn, nil
+}
+
+// parseFunctionArgument parses the literal, filter query, function or logical expression
+func (p *jsonPathParser) parseFunctionArgument() (jsonPathExpr, error) {
+	start := p.pos
+	expr, err := p.parseBasic()
+	if err != nil {
+		return nil, err
+	}
+	next := p.pos
+	p.skipSpaces()
+	if !strings.HasPrefix(p.input[p.pos:], "&&") && !strings.HasPrefix(p.input[p.pos:], "||") {
+		p.pos = next
+		return expr, nil
+	}
+	p.pos = start
+	return p.parseLogicalOr()
+}
+
+func (p *jsonPathParser) checkLogical(expr jsonPathExpr) error {
+	switch x := expr.(type) {
+	case jsonPathLiteral:
+		return p.errorf("literal must be compared")
+	case jsonPathFunctionExpr:
+		if x.def.result == jsonPathValueType {
+			return p.errorf("result of function %s must be compared", x.name)
+		}
+	}
+	return nil
+}
+
+func (p *jsonPathParser) checkComparable(expr jsonPathExpr) error {
+	switch x := expr.(type) {
+	case jsonPathLiteral:
+		return nil
+	case jsonPathQueryExpr:
+		if !x.path.singular() {
+			return p.errorf("non singular query can't be compared")
+		}
+		return nil
+	case jsonPathFunctionExpr:
+		if x.def.result != jsonPathValueType {
+			return p.errorf("result of function %s can't be compared", x.name)
+		}
+		return nil
+	}
+	return p.errorf("invalid comparable")
+}
+
+func (p *jsonPathParser) checkArgument(arg jsonPathExpr, param jsonPathType) error {
+	switch param {
+	case jsonPathValueType:
+		return p.checkComparable(arg)
+	case jsonPathNodesType:
+		if _, ok := arg.(jsonPathQueryExpr); !ok {
+			return errors.New("expected filter query")
+		}
+		return nil
+	default:
+		return p.checkLogical(arg)
+	}
+}
+
+//#endregion
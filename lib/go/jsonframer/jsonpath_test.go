@@ -0,0 +1,67 @@
+package jsonframer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/jsonframer"
+)
+
+// skippedComplianceTests are the upstream compliance cases that are not supported, by name
+var skippedComplianceTests = map[string]string{}
+
+type jsonPathTestSuite struct {
+	Tests []struct {
+		Name            string  `json:"name"`
+		Selector        string  `json:"selector"`
+		Document        any     `json:"document"`
+		Result          []any   `json:"result"`
+		Results         [][]any `json:"results"`
+		InvalidSelector bool    `json:"invalid_selector"`
+	} `json:"tests"`
+}
+
+// TestQueryJSONPath runs the cases of testdata/jsonpath/cases.json and the upstream RFC 9535 compliance suite vendored as
+// testdata/jsonpath/cts.json from https://github.com/jsonpath-standard/jsonpath-compliance-test-suite.
+// When the result order is not defined, any of the results are accepted
+func TestQueryJSONPath(t *testing.T) {
+	t.Run("cases", func(t *testing.T) {
+		runJSONPathTestSuite(t, "testdata/jsonpath/cases.json", nil)
+	})
+	t.Run("compliance", func(t *testing.T) {
+		if _, err := os.Stat("testdata/jsonpath/cts.json"); errors.Is(err, os.ErrNotExist) {
+			t.Skip("compliance suite is not vendored. see testdata/jsonpath/README.md")
+		}
+		runJSONPathTestSuite(t, "testdata/jsonpath/cts.json", skippedComplianceTests)
+	})
+}
+
+func runJSONPathTestSuite(t *testing.T, fileName string, skipped map[string]string) {
+	t.Helper()
+	b, err := os.ReadFile(fileName)
+	require.Nil(t, err)
+	var suite jsonPathTestSuite
+	require.Nil(t, json.Unmarshal(b, &suite))
+	require.NotEmpty(t, suite.Tests)
+	for _, tt := range suite.Tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			if reason, ok := skipped[tt.Name]; ok {
+				t.Skip(reason)
+			}
+			got, err := jsonframer.QueryJSONPath(tt.Document, tt.Selector)
+			if tt.InvalidSelector {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			if tt.Results != nil {
+				require.Contains(t, tt.Results, got)
+				return
+			}
+			require.Equal(t, tt.Result, got)
+		})
+	}
+}
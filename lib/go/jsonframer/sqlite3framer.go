@@ -2,11 +2,16 @@ package jsonframer
 
 import (
 	"bytes"
+	"errors"
 	"strings"
 
 	"github.com/noborus/trdsql"
 )
 
+// QueryJSONUsingSQLite3 queries the json document imported as the table `input` using the cgo sqlite3 driver.
+// Input without any columns, such as an empty array, can't be imported by trdsql. The query is not run in that case
+// and "[]" is returned regardless of the query, as this framer always did. Use FramerTypeSQLite to get the errors of
+// such queries reported.
 func QueryJSONUsingSQLite3(jsonString string, query string, rootSelector string) (string, error) {
 	r := bytes.NewBufferString(jsonString)
 	options := []trdsql.ReadOpt{}
@@ -26,8 +31,7 @@ func QueryJSONUsingSQLite3(jsonString string, query string, rootSelector string)
 	trd := trdsql.NewTRDSQL(importer, trdsql.NewExporter(writer))
 	trd.Driver = "sqlite3"
 	if err = trd.Exec(query); err != nil {
-		a := err.Error()
-		if a == "import: invalid names" {
+		if errors.Is(err, trdsql.ErrInvalidNames) {
 			return "[]", nil
 		}
 		return "", err
@@ -0,0 +1,56 @@
+package jsonframer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/jsonframer"
+)
+
+func TestQueryJSONUsingSQLite3(t *testing.T) {
+	tests := []struct {
+		name         string
+		jsonString   string
+		rootSelector string
+		query        string
+		want         string
+		wantErr      string
+	}{
+		{
+			name:       "empty array should return empty result",
+			jsonString: `[]`,
+			query:      `SELECT * FROM input`,
+			want:       `[]`,
+		},
+		{
+			name:       "empty array should return empty result regardless of the query",
+			jsonString: `[]`,
+			query:      `SELECT name FROM input`,
+			want:       `[]`,
+		},
+		{
+			name:         "empty array from the root selector should return empty result",
+			jsonString:   `{"users":[]}`,
+			rootSelector: "users",
+			query:        `SELECT * FROM input`,
+			want:         `[]`,
+		},
+		{
+			name:       "missing column should throw error",
+			jsonString: `[{"name":"foo"}]`,
+			query:      `SELECT age FROM input`,
+			wantErr:    "no such column: age",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsonframer.QueryJSONUsingSQLite3(tt.jsonString, tt.query, tt.rootSelector)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, got)
+		})
+	}
+}
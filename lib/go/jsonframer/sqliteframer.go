@@ -0,0 +1,429 @@
+package jsonframer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/tidwall/gjson"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+var sqlTableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SQLiteEngine is the in-memory pure go (no cgo) sqlite database. JSON documents are registered as tables and
+// can be joined in a single query. Columns are imported with the types inferred from the json values
+type SQLiteEngine struct {
+	db   *sql.DB
+	conn *sql.Conn
+}
+
+// NewSQLiteEngine returns the in-memory sqlite database. Close the engine once the queries are done
+func NewSQLiteEngine() (*SQLiteEngine, error) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		return nil, fmt.Errorf("error opening sqlite database. %w", err)
+	}
+	// each connection of in-memory sqlite has its own database. single connection keeps all the tables together
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error opening sqlite database. %w", err)
+	}
+	// queries must not attach database files. VACUUM INTO attaches the target file as well
+	if _, err := sqlite.Limit(conn, sqlite3.SQLITE_LIMIT_ATTACHED, 0); err != nil {
+		conn.Close()
+		db.Close()
+		return nil, fmt.Errorf("error opening sqlite database. %w", err)
+	}
+	return &SQLiteEngine{db: db, conn: conn}, nil
+}
+
+// Close closes the database and releases the registered tables
+func (e *SQLiteEngine) Close() error {
+	e.conn.Close()
+	return e.db.Close()
+}
+
+// Register imports the json document as the table. Root selector of the selector type is applied before importing.
+// Array of objects are imported as rows, object is imported as single row and other values are imported to the `value` column.
+// Numbers are imported as INTEGER / REAL, booleans as BOOLEAN, strings as TEXT and nested objects / arrays as JSON text.
+// Columns with mixed types are imported without declared type. Column names are case insensitive in sqlite, so keys that
+// differ only by case are imported with numeric suffix. For example `Name` and `name` are imported as `Name` and `name_2`
+func (e *SQLiteEngine) Register(name string, jsonString string, rootSelector string, selectorType SelectorType) error {
+	if !sqlTableName.MatchString(name) {
+		return fmt.Errorf("invalid table name %q", name)
+	}
+	rootData, err := getRootData(jsonString, rootSelector, selectorType)
+	if err != nil {
+		return fmt.Errorf("error importing table %q. %w", name, err)
+	}
+	var input any
+	if err := json.Unmarshal([]byte(rootData), &input); err != nil {
+		return fmt.Errorf("error importing table %q. invalid json. %w", name, err)
+	}
+	rows := toSQLRows(input)
+	columns := toSQLColumns(rows)
+	ctx := context.Background()
+	tx, err := e.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error importing table %q. %w", name, err)
+	}
+	defer tx.Rollback()
+	definitions := []string{}
+	placeholders := []string{}
+	for _, c := range columns {
+		definitions = append(definitions, strings.TrimSpace(quoteSQLIdentifier(c.name)+" "+c.kind.declaredType()))
+		placeholders = append(placeholders, "?")
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s (%s)", quoteSQLIdentifier(name), strings.Join(definitions, ", "))); err != nil {
+		return fmt.Errorf("error importing table %q. %w", name, err)
+	}
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s VALUES (%s)", quoteSQLIdentifier(name), strings.Join(placeholders, ", ")))
+	if err != nil {
+		return fmt.Errorf("error importing table %q. %w", name, err)
+	}
+	defer stmt.Close()
+	for i, row := range rows {
+		values := make([]any, len(columns))
+		for j, c := range columns {
+			if values[j], err = toSQLValue(row[c.key], c.kind); err != nil {
+				return fmt.Errorf("error importing row %d of table %q. %w", i, name, err)
+			}
+		}
+		if _, err := stmt.ExecContext(ctx, values...); err != nil {
+			return fmt.Errorf("error importing row %d of table %q. %w", i, name, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error importing table %q. %w", name, err)
+	}
+	return nil
+}
+
+// Query runs the query against the registered tables and returns the rows as json array. Query must be a single
+// SELECT statement and runs read only. BOOLEAN and JSON columns of the registered tables are returned as booleans and json values
+func (e *SQLiteEngine) Query(query string) (string, error) {
+	if err := validateSQLiteQuery(query); err != nil {
+		return "", err
+	}
+	ctx := context.Background()
+	if _, err := e.conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		return "", fmt.Errorf("error executing sql query. %w", err)
+	}
+	defer e.conn.ExecContext(ctx, "PRAGMA query_only = OFF")
+	rows, err := e.conn.QueryContext(ctx, query)
+	if err != nil {
+		return "", fmt.Errorf("error executing sql query. %w", err)
+	}
+	defer rows.Close()
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return "", fmt.Errorf("error executing sql query. %w", err)
+	}
+	out := []map[string]any{}
+	for rows.Next() {
+		values := make([]any, len(columnTypes))
+		pointers := make([]any, len(columnTypes))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return "", fmt.Errorf("error reading sql query results. %w", err)
+		}
+		item := map[string]any{}
+		for i, ct := range columnTypes {
+			item[ct.Name()] = fromSQLValue(values[i], ct.DatabaseTypeName())
+		}
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("error reading sql query results. %w", err)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// QueryJSONUsingSQLite queries the json document imported as the table `input` using the pure go sqlite engine
+func QueryJSONUsingSQLite(jsonString string, query string, rootSelector string, selectorType SelectorType) (string, error) {
+	engine, err := NewSQLiteEngine()
+	if err != nil {
+		return "", err
+	}
+	defer engine.Close()
+	if err := engine.Register("input", jsonString, rootSelector, selectorType); err != nil {
+		return "", err
+	}
+	return engine.Query(query)
+}
+
+// SQLInput is the json document imported as table for the sql query. Root selector of the selector type is applied before importing
+type SQLInput struct {
+	JSON         string
+	RootSelector string
+	SelectorType SelectorType // `auto` | `gjson` | `jsonata` | `jsonpath`. Defaults to `auto`
+}
+
+// ToFrameFromSQLInputs imports each input as the table named by the map key and frames the result of the SQLite3Query of the options.
+// Queries are executed using the pure go sqlite engine. Frame name, columns and override columns of the options are applied to the results
+func ToFrameFromSQLInputs(inputs map[string]SQLInput, options FramerOptions) (*data.Frame, error) {
+	if len(inputs) == 0 {
+		return nil, errors.New("invalid/empty sql inputs")
+	}
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	engine, err := NewSQLiteEngine()
+	if err != nil {
+		return nil, err
+	}
+	defer engine.Close()
+	for _, name := range names {
+		input := inputs[name]
+		if strings.TrimSpace(input.JSON) == "" {
+			return nil, fmt.Errorf("empty json received for table %q", name)
+		}
+		if !gjson.Valid(input.JSON) {
+			return nil, fmt.Errorf("invalid json response received for table %q", name)
+		}
+		if err := engine.Register(name, input.JSON, input.RootSelector, input.SelectorType); err != nil {
+			return nil, err
+		}
+	}
+	outString, err := engine.Query(options.SQLite3Query)
+	if err != nil {
+		return nil, err
+	}
+	return getFrameFromResponseString(outString, options)
+}
+
+// validateSQLiteQuery allows single SELECT statement. WITH and VALUES are allowed as they start the select statement as well.
+// Writes of the common table expressions are rejected by the read only connection
+func validateSQLiteQuery(query string) error {
+	keyword, ended := "", false
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			i += end
+			continue
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return errors.New("invalid sql query. unterminated comment")
+			}
+			i += end + 4
+			continue
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+			continue
+		case c == ';':
+			ended = keyword != ""
+			i++
+			continue
+		}
+		if ended {
+			return errors.New("invalid sql query. only single statement is allowed")
+		}
+		switch {
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			end := strings.IndexByte(query[i+1:], closing)
+			if end < 0 {
+				return errors.New("invalid sql query. unterminated quote")
+			}
+			i += end + 2
+		case keyword == "":
+			start := i
+			for i < len(query) && (query[i] >= 'a' && query[i] <= 'z' || query[i] >= 'A' && query[i] <= 'Z') {
+				i++
+			}
+			keyword = strings.ToUpper(query[start:i])
+			if keyword != "SELECT" && keyword != "WITH" && keyword != "VALUES" {
+				return fmt.Errorf("invalid sql query. only SELECT statement is allowed. received %q", query[start:max(i, start+1)])
+			}
+		default:
+			i++
+		}
+	}
+	if keyword == "" {
+		return errors.New("invalid/empty sql query")
+	}
+	return nil
+}
+
+type sqlColumnKind int
+
+const (
+	sqlColumnKindNull sqlColumnKind = iota
+	sqlColumnKindInteger
+	sqlColumnKindReal
+	sqlColumnKindText
+	sqlColumnKindBoolean
+	sqlColumnKindJSON
+	sqlColumnKindMixed
+)
+
+func (k sqlColumnKind) declaredType() string {
+	switch k {
+	case sqlColumnKindInteger:
+		return "INTEGER"
+	case sqlColumnKindReal:
+		return "REAL"
+	case sqlColumnKindText:
+		return "TEXT"
+	case sqlColumnKindBoolean:
+		return "BOOLEAN"
+	case sqlColumnKindJSON:
+		return "JSON"
+	default:
+		return ""
+	}
+}
+
+type sqlColumn struct {
+	key  string
+	name string
+	kind sqlColumnKind
+}
+
+func toSQLRows(input any) []map[string]any {
+	items := []any{input}
+	if a, ok := input.([]any); ok {
+		items = a
+	}
+	rows := []map[string]any{}
+	for _, item := range items {
+		if o, ok := item.(map[string]any); ok {
+			rows = append(rows, o)
+			continue
+		}
+		rows = append(rows, map[string]any{"value": item})
+	}
+	return rows
+}
+
+// toSQLColumns returns the sorted columns of the rows. Integers and reals are imported as REAL together, other mixed types have no declared type
+func toSQLColumns(rows []map[string]any) []sqlColumn {
+	kinds := map[string]sqlColumnKind{}
+	for _, row := range rows {
+		for k, v := range row {
+			current, next := kinds[k], getSQLColumnKind(v)
+			switch {
+			case next == sqlColumnKindNull || current == next:
+				kinds[k] = current
+			case current == sqlColumnKindNull:
+				kinds[k] = next
+			case (current == sqlColumnKindInteger || current == sqlColumnKindReal) && (next == sqlColumnKindInteger || next == sqlColumnKindReal):
+				kinds[k] = sqlColumnKindReal
+			default:
+				kinds[k] = sqlColumnKindMixed
+			}
+		}
+	}
+	if len(kinds) == 0 {
+		return []sqlColumn{{key: "value", name: "value"}}
+	}
+	columns := []sqlColumn{}
+	for k, kind := range kinds {
+		columns = append(columns, sqlColumn{key: k, name: k, kind: kind})
+	}
+	sort.Slice(columns, func(i, j int) bool { return columns[i].key < columns[j].key })
+	names := map[string]bool{}
+	for _, c := range columns {
+		names[strings.ToLower(c.key)] = true
+	}
+	seen := map[string]bool{}
+	for i, c := range columns {
+		if seen[strings.ToLower(c.name)] {
+			for suffix := 2; names[strings.ToLower(c.name)]; suffix++ {
+				c.name = fmt.Sprintf("%s_%d", c.key, suffix)
+			}
+			names[strings.ToLower(c.name)] = true
+			columns[i] = c
+		}
+		seen[strings.ToLower(c.name)] = true
+	}
+	return columns
+}
+
+func getSQLColumnKind(value any) sqlColumnKind {
+	switch v := value.(type) {
+	case nil:
+		return sqlColumnKindNull
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= 1<<53 {
+			return sqlColumnKindInteger
+		}
+		return sqlColumnKindReal
+	case string:
+		return sqlColumnKindText
+	case bool:
+		return sqlColumnKindBoolean
+	default:
+		return sqlColumnKindJSON
+	}
+}
+
+func toSQLValue(value any, kind sqlColumnKind) (any, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		if kind != sqlColumnKindReal && getSQLColumnKind(v) == sqlColumnKindInteger {
+			return int64(v), nil
+		}
+		return v, nil
+	case string, bool:
+		return v, nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+}
+
+func fromSQLValue(value any, databaseType string) any {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case int64:
+		if strings.EqualFold(databaseType, "BOOLEAN") {
+			return v != 0
+		}
+	case string:
+		if strings.EqualFold(databaseType, "JSON") {
+			var out any
+			if err := json.Unmarshal([]byte(v), &out); err == nil {
+				return out
+			}
+		}
+	}
+	return value
+}
+
+func quoteSQLIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,239 @@
+package jsonframer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/jsonframer"
+)
+
+func TestQueryJSONUsingSQLite(t *testing.T) {
+	tests := []struct {
+		name         string
+		jsonString   string
+		rootSelector string
+		selectorType jsonframer.SelectorType
+		query        string
+		want         string
+		wantErr      string
+	}{
+		{
+			name:       "columns should be imported with types",
+			jsonString: `[{"name":"foo","age":12,"score":1.5,"active":true,"tags":["a"]},{"name":"bar","age":30,"score":2,"active":false,"meta":{"a":1}}]`,
+			query:      `SELECT typeof(name) AS name, typeof(age) AS age, typeof(score) AS score, typeof(active) AS active, typeof(tags) AS tags FROM input LIMIT 1`,
+			want:       `[{"name":"text","age":"integer","score":"real","active":"integer","tags":"text"}]`,
+		},
+		{
+			name:       "numeric comparison should not use text ordering",
+			jsonString: `[{"name":"foo","age":9},{"name":"bar","age":30},{"name":"baz","age":100}]`,
+			query:      `SELECT name FROM input WHERE age > 10 ORDER BY age`,
+			want:       `[{"name":"bar"},{"name":"baz"}]`,
+		},
+		{
+			name:       "booleans and nested values should be returned as json values",
+			jsonString: `[{"name":"foo","active":true,"tags":["a","b"],"meta":{"a":1}},{"name":"bar","active":false,"tags":null}]`,
+			query:      `SELECT name, active, tags, meta, json_extract(meta, '$.a') AS a FROM input`,
+			want:       `[{"name":"foo","active":true,"tags":["a","b"],"meta":{"a":1},"a":1},{"name":"bar","active":false,"tags":null,"meta":null,"a":null}]`,
+		},
+		{
+			name:       "mixed types should retain the values",
+			jsonString: `[{"value":1},{"value":"foo"},{"value":2.5}]`,
+			query:      `SELECT value, typeof(value) AS type FROM input`,
+			want:       `[{"value":1,"type":"integer"},{"value":"foo","type":"text"},{"value":2.5,"type":"real"}]`,
+		},
+		{
+			name:         "root selector",
+			jsonString:   `{"data":{"items":[{"name":"foo"},{"name":"bar"}]}}`,
+			rootSelector: "data.items",
+			query:        `SELECT count(*) AS count FROM input`,
+			want:         `[{"count":2}]`,
+		},
+		{
+			name:         "jsonpath root selector",
+			jsonString:   `{"data":{"items":[{"name":"foo","age":12},{"name":"bar","age":30}]}}`,
+			rootSelector: "$.data.items[?(@.age > 20)]",
+			selectorType: jsonframer.SelectorTypeJSONPath,
+			query:        `SELECT name FROM input`,
+			want:         `[{"name":"bar"}]`,
+		},
+		{
+			name:       "keys differing only by case should be imported with suffix",
+			jsonString: `[{"Name":"foo","name":"bar","name_2":"baz"}]`,
+			query:      `SELECT * FROM input`,
+			want:       `[{"Name":"foo","name_3":"bar","name_2":"baz"}]`,
+		},
+		{
+			name:       "object should be imported as single row",
+			jsonString: `{"name":"foo"}`,
+			query:      `SELECT * FROM input`,
+			want:       `[{"name":"foo"}]`,
+		},
+		{
+			name:       "array of scalars should be imported as value column",
+			jsonString: `[3,1,2]`,
+			query:      `SELECT value FROM input ORDER BY value`,
+			want:       `[{"value":1},{"value":2},{"value":3}]`,
+		},
+		{
+			name:       "empty array should be imported as empty table",
+			jsonString: `[]`,
+			query:      `SELECT * FROM input`,
+			want:       `[]`,
+		},
+		{
+			name:       "invalid column should throw error",
+			jsonString: `[{"name":"foo"}]`,
+			query:      `SELECT foo FROM input`,
+			wantErr:    "error executing sql query. SQL logic error: no such column: foo (1)",
+		},
+		{
+			name:       "empty query should throw error",
+			jsonString: `[{"name":"foo"}]`,
+			wantErr:    "invalid/empty sql query",
+		},
+		{
+			name:       "trailing semicolon and comments should be allowed",
+			jsonString: `[{"name":"foo;bar"}]`,
+			query:      "-- names\nSELECT name AS [a;b] FROM input WHERE name = 'foo;bar'; /* done */ ;",
+			want:       `[{"a;b":"foo;bar"}]`,
+		},
+		{
+			name:       "common table expression should be allowed",
+			jsonString: `[{"age":12},{"age":30}]`,
+			query:      `WITH adults AS (SELECT age FROM input WHERE age > 18) SELECT count(*) AS count FROM adults`,
+			want:       `[{"count":1}]`,
+		},
+		{
+			name:       "attach should throw error",
+			jsonString: `[{"name":"foo"}]`,
+			query:      `ATTACH DATABASE '/tmp/jsonframer.db' AS leak`,
+			wantErr:    `invalid sql query. only SELECT statement is allowed. received "ATTACH"`,
+		},
+		{
+			name:       "vacuum into should throw error",
+			jsonString: `[{"name":"foo"}]`,
+			query:      `VACUUM INTO '/tmp/jsonframer.db'`,
+			wantErr:    `invalid sql query. only SELECT statement is allowed. received "VACUUM"`,
+		},
+		{
+			name:       "pragma should throw error",
+			jsonString: `[{"name":"foo"}]`,
+			query:      `PRAGMA query_only = OFF`,
+			wantErr:    `invalid sql query. only SELECT statement is allowed. received "PRAGMA"`,
+		},
+		{
+			name:       "multiple statements should throw error",
+			jsonString: `[{"name":"foo"}]`,
+			query:      `SELECT * FROM input; DROP TABLE input`,
+			wantErr:    "invalid sql query. only single statement is allowed",
+		},
+		{
+			name:       "writes of common table expression should throw error",
+			jsonString: `[{"name":"foo"}]`,
+			query:      `WITH x AS (SELECT 'bar' AS name) INSERT INTO input SELECT name FROM x`,
+			wantErr:    "error executing sql query. attempt to write a readonly database (8)",
+		},
+		{
+			name:       "unterminated quote should throw error",
+			jsonString: `[{"name":"foo"}]`,
+			query:      `SELECT 'foo; DROP TABLE input`,
+			wantErr:    "invalid sql query. unterminated quote",
+		},
+		{
+			name:         "missing root selector should throw error",
+			jsonString:   `{"data":{}}`,
+			rootSelector: "data.items",
+			query:        `SELECT * FROM input`,
+			wantErr:      `error importing table "input". root object doesn't exist in the response. Root selector:data.items`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsonframer.QueryJSONUsingSQLite(tt.jsonString, tt.query, tt.rootSelector, tt.selectorType)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.JSONEq(t, tt.want, got)
+		})
+	}
+}
+
+func TestSQLiteEngine(t *testing.T) {
+	engine, err := jsonframer.NewSQLiteEngine()
+	require.Nil(t, err)
+	defer engine.Close()
+	require.Nil(t, engine.Register("monitors", `{"monitors":[{"uuid":"m1","name":"api"},{"uuid":"m2","name":"web"}]}`, "monitors", jsonframer.SelectorTypeGJSON))
+	require.Nil(t, engine.Register("windows", `[{"monitor":"m1","title":"upgrade"},{"monitor":"m1","title":"backup"}]`, "", ""))
+	got, err := engine.Query(`SELECT m.name, count(w.title) AS windows FROM monitors m LEFT JOIN windows w ON w.monitor = m.uuid GROUP BY m.name ORDER BY m.name`)
+	require.Nil(t, err)
+	require.JSONEq(t, `[{"name":"api","windows":2},{"name":"web","windows":0}]`, got)
+	require.EqualError(t, engine.Register("input; DROP TABLE monitors", `[]`, "", ""), `invalid table name "input; DROP TABLE monitors"`)
+	require.ErrorContains(t, engine.Register("monitors", `[]`, "", ""), `error importing table "monitors". SQL logic error: table "monitors" already exists`)
+	_, err = engine.Query(`SELECT * FROM sqlite_master WHERE 0; ATTACH DATABASE '/tmp/jsonframer.db' AS leak`)
+	require.EqualError(t, err, "invalid sql query. only single statement is allowed")
+	_, err = engine.Query(`SELECT * FROM windows`)
+	require.Nil(t, err)
+	require.Nil(t, engine.Register("users", `[{"name":"foo"}]`, "", ""), "tables should be registered after the read only query")
+}
+
+func TestToFrameWithSQLite(t *testing.T) {
+	gotFrame, err := jsonframer.ToFrame(`{"users":[{"name":"foo","age":12},{"name":"bar","age":30}]}`, jsonframer.FramerOptions{
+		FramerType:   jsonframer.FramerTypeSQLite,
+		RootSelector: "users",
+		SQLite3Query: "SELECT name, age * 2 AS double FROM input WHERE age > 20",
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, gotFrame.Rows())
+	double, _ := gotFrame.FieldByName("double")
+	require.NotNil(t, double)
+	value, _ := double.ConcreteAt(0)
+	require.Equal(t, 60.0, value)
+}
+
+func TestToFrameFromSQLInputs(t *testing.T) {
+	inputs := map[string]jsonframer.SQLInput{
+		"monitors": {JSON: `{"monitors":[{"uuid":"m1","name":"api"},{"uuid":"m2","name":"web"}]}`, RootSelector: "monitors"},
+		"windows":  {JSON: `{"maintenanceWindows":[{"monitorUuid":"m1","name":"upgrade","start_date":"2023-01-02T10:00:00Z"}]}`, RootSelector: "$.maintenanceWindows[*]", SelectorType: jsonframer.SelectorTypeJSONPath},
+	}
+	t.Run("should join the inputs", func(t *testing.T) {
+		gotFrame, err := jsonframer.ToFrameFromSQLInputs(inputs, jsonframer.FramerOptions{
+			FrameName:    "A",
+			SQLite3Query: "SELECT m.name AS monitor, w.name AS window, w.start_date AS start FROM monitors m LEFT JOIN windows w ON w.monitorUuid = m.uuid ORDER BY m.name",
+			Columns: []jsonframer.ColumnSelector{
+				{Selector: "monitor", Type: "string"},
+				{Selector: "window", Type: "string"},
+				{Selector: "start", Type: "timestamp"},
+			},
+		})
+		require.Nil(t, err)
+		require.Equal(t, "A", gotFrame.Name)
+		require.Equal(t, 2, gotFrame.Rows())
+		monitor, _ := gotFrame.FieldByName("monitor")
+		require.NotNil(t, monitor)
+		value, _ := monitor.ConcreteAt(1)
+		require.Equal(t, "web", value)
+		window, _ := gotFrame.FieldByName("window")
+		require.NotNil(t, window)
+		value, ok := window.ConcreteAt(1)
+		require.False(t, ok, value)
+		start, _ := gotFrame.FieldByName("start")
+		require.NotNil(t, start)
+		value, _ = start.ConcreteAt(0)
+		require.Equal(t, time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC), value)
+	})
+	t.Run("invalid input should throw error", func(t *testing.T) {
+		_, err := jsonframer.ToFrameFromSQLInputs(map[string]jsonframer.SQLInput{"monitors": inputs["monitors"], "windows": {JSON: `{`}}, jsonframer.FramerOptions{SQLite3Query: "SELECT * FROM monitors"})
+		require.EqualError(t, err, `invalid json response received for table "windows"`)
+	})
+	t.Run("invalid query should throw error", func(t *testing.T) {
+		_, err := jsonframer.ToFrameFromSQLInputs(inputs, jsonframer.FramerOptions{SQLite3Query: "SELECT * FROM users"})
+		require.EqualError(t, err, "error executing sql query. SQL logic error: no such table: users (1)")
+	})
+	t.Run("empty inputs should throw error", func(t *testing.T) {
+		_, err := jsonframer.ToFrameFromSQLInputs(nil, jsonframer.FramerOptions{SQLite3Query: "SELECT 1"})
+		require.EqualError(t, err, "invalid/empty sql inputs")
+	})
+}
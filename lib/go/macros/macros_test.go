@@ -46,3 +46,24 @@ func TestApplyMacros(t *testing.T) {
 		})
 	}
 }
+
+func TestToGoTimeLayout(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "YYYY-MM-DD HH:mm:ss", want: "2006-01-02 15:04:05"},
+		{format: "DD/MM/YY hh:mm A", want: "02/01/06 03:04 PM"},
+		{format: "dddd, MMMM D YYYY", want: "Monday, January 2 2006"},
+		{format: "%Y-%m-%dT%H:%M:%S%z", want: "2006-01-02T15:04:05-0700"},
+		{format: "%d/%b/%Y:%H:%M:%S.%f %Z", want: "02/Jan/2006:15:04:05.000000 MST"},
+		{format: "%F %T %p %%", want: "2006-01-02 15:04:05 PM %"},
+		{format: "%Q %", want: "%Q %"},
+		{format: "2006-01-02T15:04:05Z07:00", want: "2006-01-02T15:04:05Z07:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			require.Equal(t, tt.want, macros.ToGoTimeLayout(tt.format))
+		})
+	}
+}
@@ -52,6 +52,26 @@ func expandTimeMacro(t time.Time, args []string) (string, error) {
 	if format == "seconds" {
 		return fmt.Sprintf("%d", t.Unix()), nil
 	}
+	return t.Format(ToGoTimeLayout(format)), nil
+}
+
+var strftimeLayouts = map[byte]string{
+	'Y': "2006", 'y': "06",
+	'B': "January", 'b': "Jan", 'h': "Jan", 'm': "01",
+	'd': "02", 'e': "_2", 'j': "002",
+	'A': "Monday", 'a': "Mon",
+	'H': "15", 'I': "03", 'M': "04", 'S': "05", 'f': "000000", 'p': "PM",
+	'z': "-0700", 'Z': "MST",
+	'F': "2006-01-02", 'T': "15:04:05", 'D': "01/02/06", 'R': "15:04",
+	'%': "%",
+}
+
+// ToGoTimeLayout converts the moment style (YYYY-MM-DD) or strftime style (%Y-%m-%d) time format into go time layout.
+// Formats with % are treated as strftime. Go layouts without any of the moment tokens are returned as is
+func ToGoTimeLayout(format string) string {
+	if strings.Contains(format, "%") {
+		return strftimeToGoTimeLayout(format)
+	}
 	format = strings.ReplaceAll(format, "YYYY", "2006")
 	format = strings.ReplaceAll(format, "YY", "06")
 
@@ -83,5 +103,23 @@ func expandTimeMacro(t time.Time, args []string) (string, error) {
 	format = strings.ReplaceAll(format, "dddd", "Monday")
 	format = strings.ReplaceAll(format, "ddd", "Mon")
 
-	return t.Format(format), nil
+	return format
+}
+
+func strftimeToGoTimeLayout(format string) string {
+	var sb strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			sb.WriteByte(format[i])
+			continue
+		}
+		i++
+		if layout, ok := strftimeLayouts[format[i]]; ok {
+			sb.WriteString(layout)
+			continue
+		}
+		sb.WriteByte('%')
+		sb.WriteByte(format[i])
+	}
+	return sb.String()
 }
@@ -1,6 +1,7 @@
 package restds
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -25,6 +26,23 @@ func (ds *pluginHost) GetRouter(restDriver RestDriver, restDriverOptions RestDri
 			backend.Logger.Error("error writing resource call response", "path", "/openapi3", "error", err.Error())
 		}
 	})
+	router.HandleFunc("/presets", func(w http.ResponseWriter, r *http.Request) {
+		presets, err := loadPresets(restDriver)
+		if err != nil {
+			backend.Logger.Error("error loading presets", "error", err.Error())
+			w.WriteHeader(500)
+			return
+		}
+		res, err := json.Marshal(presets)
+		if err != nil {
+			w.WriteHeader(500)
+			return
+		}
+		w.Header().Set(HeaderKeyContentType, "application/json")
+		if _, err := w.Write(res); err != nil {
+			backend.Logger.Error("error writing resource call response", "path", "/presets", "error", err.Error())
+		}
+	})
 	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		backend.Logger.Debug("resource call received", "url", r.URL.String())
 		w.WriteHeader(http.StatusNotFound)
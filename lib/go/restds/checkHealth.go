@@ -21,7 +21,7 @@ func (ds *pluginHost) CheckHealth(ctx context.Context, req *backend.CheckHealthR
 			Message: fmt.Sprintf("%s datasource plugin works", dsi.PluginName),
 		}, nil
 	}
-	responseString, meta, err := dsi.RestDS.GetResponse(Query{URL: dsi.Options.HealthCheckURL})
+	responseString, meta, err := dsi.RestDS.GetResponseWithContext(ctx, Query{URL: dsi.Options.HealthCheckURL})
 	if err != nil {
 		return &backend.CheckHealthResult{
 			Status:  backend.HealthStatusError,
@@ -10,6 +10,7 @@ const (
 	AuthTypeForwardOauth AuthType = "oauthPassThru"
 	AuthTypeDigestAuth   AuthType = "digestAuth"
 	AuthTypeOAuth2       AuthType = "oauth2"
+	AuthTypeSessionLogin AuthType = "sessionLogin"
 )
 
 type APIKeyType string
@@ -37,6 +38,8 @@ type Config struct {
 	BearerToken          string
 	Headers              map[string]string
 	QueryParams          map[string]string
+	SecureValues         map[string]string
+	Recording            Recording
 	OAuth2Settings       struct {
 		Type           OAuth2Type
 		TokenURL       string
@@ -49,6 +52,14 @@ type Config struct {
 		Scopes         []string
 		EndpointParams map[string]string
 	}
+	SessionLoginSettings struct {
+		LoginURL      string
+		UsernameField string
+		PasswordField string
+		Username      string
+		Password      string
+		FormFields    map[string]string
+	}
 }
 
 func (c *Config) Validate() error {
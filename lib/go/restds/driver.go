@@ -16,3 +16,8 @@ type RestDriver interface {
 	LoadConfig(settings backend.DataSourceInstanceSettings) (*Config, error)
 	LoadSpec() openapi3.Spec
 }
+
+// PresetProvider is an optional interface a RestDriver can implement to ship named queries
+type PresetProvider interface {
+	LoadPresets() []Preset
+}
@@ -2,11 +2,27 @@ package restds
 
 import (
 	"net/http"
+	"net/http/cookiejar"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend/httpclient"
 )
 
 func NewHTTPClient(config *Config) *http.Client {
-	hc, _ := httpclient.New()
+	defaultClient, _ := httpclient.New()
+	// httpclient.New returns the shared http.DefaultClient. copy it so the per instance customizations don't leak
+	client := *defaultClient
+	hc := &client
+	if config != nil && config.AuthenticationMethod == AuthTypeSessionLogin {
+		// cookie jar is per instance, so the session cookies are shared across the queries of the datasource
+		jar, _ := cookiejar.New(nil)
+		hc.Jar = jar
+	}
+	recording := RecordingFromEnv()
+	if config != nil && config.Recording.Mode != "" {
+		recording = config.Recording
+	}
+	if recording.enabled() {
+		hc.Transport = newRecorder(recording, config, hc.Transport)
+	}
 	return hc
 }
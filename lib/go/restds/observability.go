@@ -0,0 +1,105 @@
+package restds
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	metricsNamespace = "grafana_plugin"
+	metricsSubsystem = "restds"
+)
+
+// upstream metrics are registered in the default prometheus registry, which is exposed by the plugin sdk
+var (
+	upstreamRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "upstream_requests_total",
+		Help:      "Total number of upstream requests",
+	}, []string{"plugin_id", "host", "method", "status_code"})
+	upstreamRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Duration of the upstream requests in seconds",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"plugin_id", "host", "method"})
+	upstreamRequestErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "upstream_request_errors_total",
+		Help:      "Total number of failed upstream requests",
+	}, []string{"plugin_id", "host", "method"})
+)
+
+// metricsHostOther is the host label of the upstream requests to the hosts other than the config base url host
+const metricsHostOther = "other"
+
+type upstreamCall struct {
+	pluginID string
+	host     string
+	req      *http.Request
+	span     trace.Span
+	start    time.Time
+}
+
+// startUpstreamCall starts the span for the upstream request and propagates the trace context to the upstream
+func (restds *RestDS) startUpstreamCall(ctx context.Context, req *http.Request) (*http.Request, *upstreamCall) {
+	ctx, span := tracing.DefaultTracer().Start(ctx, "restds upstream request", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("plugin_id", restds.PluginID),
+		attribute.String("http.method", req.Method),
+		attribute.String("http.url", restds.Config.Redact(req.URL.String())),
+		attribute.String("net.peer.name", req.URL.Host),
+	))
+	req = req.WithContext(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return req, &upstreamCall{pluginID: restds.PluginID, host: restds.Config.metricsHost(req.URL), req: req, span: span, start: time.Now()}
+}
+
+// metricsHost returns the host label for the upstream metrics. Query urls are user controlled,
+// so hosts other than the config base url host are bucketed as "other" to keep the label cardinality bounded
+func (c *Config) metricsHost(u *url.URL) string {
+	base, err := url.Parse(c.BaseURL)
+	if err != nil || base.Host == "" || !strings.EqualFold(base.Host, u.Host) {
+		return metricsHostOther
+	}
+	return strings.ToLower(base.Host)
+}
+
+// end records the logs, metrics and span status of the upstream call
+func (call *upstreamCall) end(redact func(string) string, statusCode int, bytes int, err error) {
+	duration := time.Since(call.start)
+	host := call.host
+	method := call.req.Method
+	upstreamRequestDuration.WithLabelValues(call.pluginID, host, method).Observe(duration.Seconds())
+	upstreamRequestsTotal.WithLabelValues(call.pluginID, host, method, strconv.Itoa(statusCode)).Inc()
+	call.span.SetAttributes(attribute.Int("http.status_code", statusCode), attribute.Int("http.response_content_length", bytes))
+	if err != nil || statusCode >= http.StatusBadRequest {
+		upstreamRequestErrorsTotal.WithLabelValues(call.pluginID, host, method).Inc()
+	}
+	if err != nil {
+		call.span.RecordError(err)
+		call.span.SetStatus(codes.Error, redact(err.Error()))
+	}
+	call.span.End()
+	args := []any{"pluginId", call.pluginID, "method", method, "url", redact(call.req.URL.String()), "status", statusCode, "duration", duration.String(), "bytes", bytes}
+	if err != nil {
+		args = append(args, "error", redact(err.Error()))
+	}
+	backend.Logger.FromContext(call.req.Context()).Debug("upstream request", args...)
+}
@@ -0,0 +1,83 @@
+package restds_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/restds"
+)
+
+func TestRestDS_GetResponse_Metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	ds := restds.RestDS{HTTPClient: server.Client(), PluginID: "metrics-test-plugin"}
+	_, _, err := ds.GetResponseWithContext(context.Background(), restds.Query{URL: server.URL + "/ok"})
+	require.Nil(t, err)
+	_, _, err = ds.GetResponseWithContext(context.Background(), restds.Query{URL: server.URL + "/error"})
+	require.NotNil(t, err)
+	metrics, err := prometheus.DefaultGatherer.Gather()
+	require.Nil(t, err)
+	got := map[string]float64{}
+	for _, mf := range metrics {
+		for _, m := range mf.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "plugin_id" && l.GetValue() == "metrics-test-plugin" {
+					switch {
+					case m.GetCounter() != nil:
+						got[mf.GetName()] += m.GetCounter().GetValue()
+					case m.GetHistogram() != nil:
+						got[mf.GetName()] += float64(m.GetHistogram().GetSampleCount())
+					}
+				}
+			}
+		}
+	}
+	require.Equal(t, float64(2), got["grafana_plugin_restds_upstream_requests_total"])
+	require.Equal(t, float64(2), got["grafana_plugin_restds_upstream_request_duration_seconds"])
+	require.Equal(t, float64(1), got["grafana_plugin_restds_upstream_request_errors_total"])
+}
+
+func TestRestDS_GetResponse_MetricsHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	ds := restds.RestDS{Config: restds.Config{BaseURL: server.URL}, HTTPClient: server.Client(), PluginID: "metrics-host-test-plugin"}
+	_, _, err := ds.GetResponseWithContext(context.Background(), restds.Query{URL: server.URL + "/ok"})
+	require.Nil(t, err)
+	otherURL := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	for i := 0; i < 3; i++ {
+		_, _, err = ds.GetResponseWithContext(context.Background(), restds.Query{URL: fmt.Sprintf("%s/other-%d", otherURL, i)})
+		require.Nil(t, err)
+	}
+	metrics, err := prometheus.DefaultGatherer.Gather()
+	require.Nil(t, err)
+	got := map[string]float64{}
+	for _, mf := range metrics {
+		if mf.GetName() != "grafana_plugin_restds_upstream_requests_total" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["plugin_id"] == "metrics-host-test-plugin" {
+				got[labels["host"]] += m.GetCounter().GetValue()
+			}
+		}
+	}
+	require.Equal(t, map[string]float64{strings.TrimPrefix(server.URL, "http://"): 1, "other": 3}, got)
+}
@@ -2,6 +2,9 @@ package restds
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
 
 	"github.com/swaggest/openapi-go/openapi3"
 )
@@ -11,3 +14,31 @@ func SpecFromJson(jsonString string) (openapi3.Spec, error) {
 	err := json.Unmarshal([]byte(jsonString), &spec)
 	return spec, err
 }
+
+// SpecFromURL loads the spec from the given url. Recording settings from the environment are respected
+func SpecFromURL(specURL string) (openapi3.Spec, error) {
+	spec := openapi3.Spec{Openapi: "3.0.3"}
+	req, err := http.NewRequest(http.MethodGet, specURL, nil)
+	if err != nil {
+		return spec, err
+	}
+	res, err := NewHTTPClient(&Config{}).Do(req)
+	if err != nil {
+		return spec, err
+	}
+	if res != nil {
+		defer res.Body.Close()
+		bodyBytes, err := io.ReadAll(res.Body)
+		if err != nil {
+			return spec, err
+		}
+		if res.StatusCode >= http.StatusBadRequest {
+			return spec, errors.New("invalid status code." + res.Status)
+		}
+		if err := json.Unmarshal(bodyBytes, &spec); err != nil {
+			return spec, err
+		}
+		return spec, nil
+	}
+	return spec, errors.New("invalid/empty status")
+}
@@ -17,6 +17,7 @@ type datasourceInstance struct {
 	PluginID   string
 	Options    RestDriverOptions
 	RestDS     RestDS
+	Presets    []Preset
 }
 
 func getInstance(ctx context.Context, pluginCtx backend.PluginContext, im instancemgmt.InstanceManager) (*datasourceInstance, error) {
@@ -31,6 +32,20 @@ func (ins *datasourceInstance) Dispose() {
 	backend.Logger.Debug("disposing plugin instance")
 }
 
+func loadPresets(restDriver RestDriver) ([]Preset, error) {
+	presetProvider, ok := restDriver.(PresetProvider)
+	if !ok {
+		return []Preset{}, nil
+	}
+	presets := presetProvider.LoadPresets()
+	for _, p := range presets {
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return presets, nil
+}
+
 func NewPlugin(restDriver RestDriver, restDriverOptions RestDriverOptions) datasource.ServeOpts {
 	pluginHost := &pluginHost{
 		IM: datasource.NewInstanceManager(func(ctx context.Context, settings backend.DataSourceInstanceSettings) (instancemgmt.Instance, error) {
@@ -41,12 +56,20 @@ func NewPlugin(restDriver RestDriver, restDriverOptions RestDriverOptions) datas
 			if config == nil {
 				return nil, fmt.Errorf("error loading config. %w", errors.New("invalid/empty config"))
 			}
-			restDs := &RestDS{Config: *config, HTTPClient: NewHTTPClient(config)}
+			if config.SecureValues == nil {
+				config.SecureValues = settings.DecryptedSecureJSONData
+			}
+			presets, err := loadPresets(restDriver)
+			if err != nil {
+				return nil, fmt.Errorf("error loading presets. %w", err)
+			}
+			restDs := &RestDS{Config: *config, HTTPClient: NewHTTPClient(config), PluginID: restDriverOptions.PluginID}
 			return &datasourceInstance{
 				PluginID:   restDriverOptions.PluginID,
 				PluginName: restDriverOptions.PluginName,
 				Options:    restDriverOptions,
 				RestDS:     *restDs,
+				Presets:    presets,
 			}, nil
 		}),
 	}
@@ -0,0 +1,294 @@
+package restds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	jsonata "github.com/xiatechs/jsonata-go"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+)
+
+// Preset is a named query shipped by the driver
+type Preset struct {
+	Name         string             `json:"name"`
+	Description  string             `json:"description,omitempty"`
+	URL          string             `json:"url"`
+	Method       QueryURLMethod     `json:"method,omitempty"`
+	Headers      []KV               `json:"headers,omitempty"`
+	Variables    map[string]string  `json:"variables,omitempty"`
+	RootSelector string             `json:"rootSelector,omitempty"`
+	Columns      []anyframer.Column `json:"columns,omitempty"`
+	Pagination   Pagination         `json:"pagination,omitempty"`
+	FieldLinks   []FieldLink        `json:"fieldLinks,omitempty"`
+}
+
+// FieldLink is a data link attached to the field of the preset frame
+type FieldLink struct {
+	Field       string `json:"field"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	TargetBlank bool   `json:"targetBlank,omitempty"`
+}
+
+type PaginationType string
+
+const (
+	PaginationTypeNone   PaginationType = "none"
+	PaginationTypePage   PaginationType = "page"
+	PaginationTypeOffset PaginationType = "offset"
+	PaginationTypeCursor PaginationType = "cursor"
+)
+
+const defaultPaginationMaxPages = 10
+
+// Pagination defines how the preset fetches more than one page of results.
+// ParamName is the query parameter holding the page number, offset or cursor.
+// CursorSelector is the JSONata selector pointing to the next cursor in the response.
+type Pagination struct {
+	Type           PaginationType `json:"type,omitempty"`
+	ParamName      string         `json:"paramName,omitempty"`
+	SizeParamName  string         `json:"sizeParamName,omitempty"`
+	Size           int            `json:"size,omitempty"`
+	CursorSelector string         `json:"cursorSelector,omitempty"`
+	MaxPages       int            `json:"maxPages,omitempty"`
+}
+
+var presetURLVariableRegex = regexp.MustCompile(`\{([A-Za-z0-9_\-]+)\}`)
+
+func findPreset(presets []Preset, name string) (Preset, error) {
+	for _, p := range presets {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return Preset{}, fmt.Errorf("invalid/unknown preset %q", name)
+}
+
+// GetURL resolves the URL template of the preset. {baseUrl} always resolves to the config base url and never from the query variables.
+// Other variables resolve from the query variables, falling back to the preset defaults, and are path escaped
+func (p Preset) GetURL(baseURL string, variables map[string]string) (string, error) {
+	missing := []string{}
+	u := presetURLVariableRegex.ReplaceAllStringFunc(p.URL, func(match string) string {
+		key := strings.Trim(match, "{}")
+		if key == "baseUrl" {
+			if baseURL != "" {
+				return strings.TrimSuffix(baseURL, "/")
+			}
+			missing = append(missing, key)
+			return match
+		}
+		if v, ok := variables[key]; ok && v != "" {
+			return url.PathEscape(v)
+		}
+		if v, ok := p.Variables[key]; ok && v != "" {
+			return url.PathEscape(v)
+		}
+		missing = append(missing, key)
+		return match
+	})
+	if len(missing) > 0 {
+		return u, fmt.Errorf("missing value for the preset variables %s", strings.Join(missing, ", "))
+	}
+	return u, nil
+}
+
+// Validate checks the preset definition. Offset pagination requires the page size to compute the offsets
+func (p Preset) Validate() error {
+	if p.Pagination.Type == PaginationTypeOffset && p.Pagination.Size <= 0 {
+		return fmt.Errorf("invalid preset %q. offset pagination requires a positive page size", p.Name)
+	}
+	return nil
+}
+
+// GetPresetFrame executes the preset and converts the response(s) into a frame
+func (restds *RestDS) GetPresetFrame(ctx context.Context, preset Preset, query Query) (*data.Frame, error) {
+	u, err := preset.GetURL(restds.Config.BaseURL, query.PresetVariables)
+	if err != nil {
+		return nil, err
+	}
+	presetQuery := Query{
+		RefID:   query.RefID,
+		URL:     u,
+		Method:  preset.Method,
+		Headers: append(append([]KV{}, preset.Headers...), query.Headers...),
+	}
+	if presetQuery.Method == "" {
+		presetQuery.Method = QueryURLMethodGet
+	}
+	var frame *data.Frame
+	var rawURLs []string
+	switch preset.Pagination.Type {
+	case "", PaginationTypeNone:
+		body, meta, err := restds.GetResponseWithContext(ctx, presetQuery)
+		if err != nil {
+			return nil, err
+		}
+		rawURLs = append(rawURLs, meta.RawURL)
+		framer := anyframer.AnyFramer{
+			InputType:    anyframer.InputTypeJSON,
+			RootSelector: preset.RootSelector,
+			Columns:      preset.Columns,
+			Headers:      meta.Headers,
+		}
+		if frame, err = framer.ToFrame(body); err != nil {
+			return nil, err
+		}
+	default:
+		items, pageURLs, err := restds.getPaginatedItems(ctx, preset, presetQuery)
+		if err != nil {
+			return nil, err
+		}
+		rawURLs = pageURLs
+		framer := anyframer.AnyFramer{Columns: preset.Columns}
+		if frame, err = framer.ToFrame(items); err != nil {
+			return nil, err
+		}
+	}
+	preset.applyFieldLinks(frame)
+	if frame.Meta == nil {
+		frame.Meta = &data.FrameMeta{}
+	}
+	for i, rawURL := range rawURLs {
+		rawURLs[i] = restds.Config.Redact(rawURL)
+	}
+	frame.Meta.ExecutedQueryString = strings.Join(rawURLs, "\n")
+	return frame, nil
+}
+
+func (restds *RestDS) getPaginatedItems(ctx context.Context, preset Preset, query Query) ([]any, []string, error) {
+	if err := preset.Validate(); err != nil {
+		return nil, nil, err
+	}
+	pagination := preset.Pagination
+	if pagination.ParamName == "" {
+		return nil, nil, errors.New("invalid/empty pagination parameter name")
+	}
+	maxPages := pagination.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultPaginationMaxPages
+	}
+	var rootSelector, cursorSelector *jsonata.Expr
+	var err error
+	if preset.RootSelector != "" {
+		if rootSelector, err = jsonata.Compile(preset.RootSelector); err != nil {
+			return nil, nil, fmt.Errorf("error compiling root selector. %w", err)
+		}
+	}
+	if pagination.Type == PaginationTypeCursor {
+		if pagination.CursorSelector == "" {
+			return nil, nil, errors.New("invalid/empty pagination cursor selector")
+		}
+		if cursorSelector, err = jsonata.Compile(pagination.CursorSelector); err != nil {
+			return nil, nil, fmt.Errorf("error compiling pagination cursor selector. %w", err)
+		}
+	}
+	items := []any{}
+	rawURLs := []string{}
+	cursor := ""
+	for page := 0; page < maxPages; page++ {
+		pageURL, err := url.Parse(query.URL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing preset url. %w", err)
+		}
+		params := pageURL.Query()
+		if pagination.SizeParamName != "" && pagination.Size > 0 {
+			params.Set(pagination.SizeParamName, strconv.Itoa(pagination.Size))
+		}
+		switch pagination.Type {
+		case PaginationTypePage:
+			params.Set(pagination.ParamName, strconv.Itoa(page+1))
+		case PaginationTypeOffset:
+			params.Set(pagination.ParamName, strconv.Itoa(page*pagination.Size))
+		case PaginationTypeCursor:
+			if cursor != "" {
+				params.Set(pagination.ParamName, cursor)
+			}
+		default:
+			return nil, nil, fmt.Errorf("invalid pagination type %q", pagination.Type)
+		}
+		pageURL.RawQuery = params.Encode()
+		pageQuery := query
+		pageQuery.URL = pageURL.String()
+		body, meta, err := restds.GetResponseWithContext(ctx, pageQuery)
+		if err != nil {
+			return nil, nil, err
+		}
+		rawURLs = append(rawURLs, meta.RawURL)
+		var response any
+		if err := json.Unmarshal([]byte(body), &response); err != nil {
+			return nil, nil, fmt.Errorf("error parsing the paginated response. %w", err)
+		}
+		pageItems := response
+		if rootSelector != nil {
+			if pageItems, err = rootSelector.Eval(response); err != nil && !errors.Is(err, jsonata.ErrUndefined) {
+				return nil, nil, fmt.Errorf("error applying root selector. %w", err)
+			}
+		}
+		pageSlice, ok := pageItems.([]any)
+		if !ok {
+			if pageItems != nil {
+				items = append(items, pageItems)
+			}
+			break
+		}
+		items = append(items, pageSlice...)
+		if len(pageSlice) == 0 {
+			break
+		}
+		if pagination.Type == PaginationTypeCursor {
+			next, err := cursorSelector.Eval(response)
+			if err != nil || next == nil {
+				break
+			}
+			if cursor = cursorToString(next); cursor == "" {
+				break
+			}
+			continue
+		}
+		if pagination.Size > 0 && len(pageSlice) < pagination.Size {
+			break
+		}
+	}
+	return items, rawURLs, nil
+}
+
+func cursorToString(cursor any) string {
+	switch c := cursor.(type) {
+	case string:
+		return c
+	case float64:
+		return strconv.FormatFloat(c, 'f', -1, 64)
+	case bool:
+		return ""
+	default:
+		return fmt.Sprintf("%v", c)
+	}
+}
+
+func (p Preset) applyFieldLinks(frame *data.Frame) {
+	if frame == nil || len(p.FieldLinks) == 0 {
+		return
+	}
+	for _, field := range frame.Fields {
+		for _, link := range p.FieldLinks {
+			if link.Field != field.Name {
+				continue
+			}
+			if field.Config == nil {
+				field.Config = &data.FieldConfig{}
+			}
+			field.Config.Links = append(field.Config.Links, data.DataLink{
+				Title:       link.Title,
+				URL:         link.URL,
+				TargetBlank: link.TargetBlank,
+			})
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package restds_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/restds"
+)
+
+func TestPreset_GetURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		preset    restds.Preset
+		baseURL   string
+		variables map[string]string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:   "url without variables",
+			preset: restds.Preset{URL: "https://foo.com/bar"},
+			want:   "https://foo.com/bar",
+		},
+		{
+			name:    "base url should be resolved from config",
+			preset:  restds.Preset{URL: "{baseUrl}/v9/projects"},
+			baseURL: "https://api.vercel.com/",
+			want:    "https://api.vercel.com/v9/projects",
+		},
+		{
+			name:      "query variables should take precedence over preset defaults",
+			preset:    restds.Preset{URL: "{baseUrl}/v2/teams/{teamId}/members", Variables: map[string]string{"teamId": "default"}},
+			baseURL:   "https://api.vercel.com",
+			variables: map[string]string{"teamId": "my-team"},
+			want:      "https://api.vercel.com/v2/teams/my-team/members",
+		},
+		{
+			name:      "base url should never be resolved from query variables",
+			preset:    restds.Preset{URL: "{baseUrl}/v9/projects"},
+			baseURL:   "https://api.vercel.com",
+			variables: map[string]string{"baseUrl": "https://evil.com"},
+			want:      "https://api.vercel.com/v9/projects",
+		},
+		{
+			name:      "missing base url should throw error",
+			preset:    restds.Preset{URL: "{baseUrl}/v9/projects"},
+			variables: map[string]string{"baseUrl": "https://evil.com"},
+			wantErr:   errors.New("missing value for the preset variables baseUrl"),
+		},
+		{
+			name:      "variables should be path escaped",
+			preset:    restds.Preset{URL: "{baseUrl}/v2/teams/{teamId}/members"},
+			baseURL:   "https://api.vercel.com",
+			variables: map[string]string{"teamId": "../admin?x=1"},
+			want:      "https://api.vercel.com/v2/teams/..%2Fadmin%3Fx=1/members",
+		},
+		{
+			name:    "missing variables should throw error",
+			preset:  restds.Preset{URL: "{baseUrl}/v2/teams/{teamId}/members"},
+			baseURL: "https://api.vercel.com",
+			wantErr: errors.New("missing value for the preset variables teamId"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.preset.GetURL(tt.baseURL, tt.variables)
+			if tt.wantErr != nil {
+				require.NotNil(t, err)
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRestDS_GetPresetFrame(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects":
+			_, _ = w.Write([]byte(`{ "projects" : [{ "id" : "p1", "name" : "foo" },{ "id" : "p2", "name" : "bar" }] }`))
+		case "/deployments":
+			switch r.URL.Query().Get("until") {
+			case "":
+				_, _ = w.Write([]byte(`{ "deployments" : [{ "uid" : "d1" },{ "uid" : "d2" }], "pagination" : { "next" : 1700000000000 } }`))
+			case "1700000000000":
+				_, _ = w.Write([]byte(`{ "deployments" : [{ "uid" : "d3" }], "pagination" : { "next" : null } }`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		case "/pets":
+			page := r.URL.Query().Get("page")
+			if page == "3" {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = w.Write([]byte(fmt.Sprintf(`[{ "name" : "pet-%s-a" },{ "name" : "pet-%s-b" }]`, page, page)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	ds := restds.RestDS{Config: restds.Config{BaseURL: server.URL}, HTTPClient: server.Client()}
+	t.Run("preset without pagination", func(t *testing.T) {
+		frame, err := ds.GetPresetFrame(context.Background(), restds.Preset{
+			Name:         "Projects",
+			URL:          "{baseUrl}/projects",
+			RootSelector: "projects",
+			Columns:      []anyframer.Column{{Selector: "id", Format: anyframer.ColumnFormatString}, {Selector: "name", Alias: "Project", Format: anyframer.ColumnFormatString}},
+			FieldLinks:   []restds.FieldLink{{Field: "id", Title: "Open project", URL: "https://foo.com/${__data.fields.id}"}},
+		}, restds.Query{})
+		require.Nil(t, err)
+		require.Equal(t, 2, frame.Rows())
+		require.Equal(t, "Project", frame.Fields[1].Name)
+		require.NotNil(t, frame.Fields[0].Config)
+		require.Equal(t, "https://foo.com/${__data.fields.id}", frame.Fields[0].Config.Links[0].URL)
+		require.Nil(t, frame.Fields[1].Config)
+		require.Equal(t, server.URL+"/projects", frame.Meta.ExecutedQueryString)
+	})
+	t.Run("preset with cursor pagination", func(t *testing.T) {
+		frame, err := ds.GetPresetFrame(context.Background(), restds.Preset{
+			Name:         "Deployments",
+			URL:          "{baseUrl}/deployments",
+			RootSelector: "deployments",
+			Pagination:   restds.Pagination{Type: restds.PaginationTypeCursor, ParamName: "until", CursorSelector: "pagination.next"},
+		}, restds.Query{})
+		require.Nil(t, err)
+		require.Equal(t, 3, frame.Rows())
+		v, _ := frame.Fields[0].ConcreteAt(2)
+		require.Equal(t, "d3", v)
+	})
+	t.Run("preset with page pagination", func(t *testing.T) {
+		frame, err := ds.GetPresetFrame(context.Background(), restds.Preset{
+			Name:       "Pets",
+			URL:        "{baseUrl}/pets",
+			Pagination: restds.Pagination{Type: restds.PaginationTypePage, ParamName: "page"},
+		}, restds.Query{})
+		require.Nil(t, err)
+		require.Equal(t, 4, frame.Rows())
+		require.Equal(t, server.URL+"/pets?page=1\n"+server.URL+"/pets?page=2\n"+server.URL+"/pets?page=3", frame.Meta.ExecutedQueryString)
+	})
+	t.Run("preset should redact the secrets of the executed urls", func(t *testing.T) {
+		config := restds.Config{
+			BaseURL:      server.URL,
+			QueryParams:  map[string]string{"application_key": "${__secure.appKey}"},
+			SecureValues: map[string]string{"appKey": "my-app-key"},
+		}
+		ds := restds.RestDS{Config: config, HTTPClient: restds.NewHTTPClient(&config)}
+		frame, err := ds.GetPresetFrame(context.Background(), restds.Preset{
+			Name:       "Pets",
+			URL:        "{baseUrl}/pets",
+			Pagination: restds.Pagination{Type: restds.PaginationTypePage, ParamName: "page", MaxPages: 1},
+		}, restds.Query{})
+		require.Nil(t, err)
+		require.Equal(t, server.URL+"/pets?application_key=__REDACTED__&page=1", frame.Meta.ExecutedQueryString)
+	})
+	t.Run("preset with max pages", func(t *testing.T) {
+		frame, err := ds.GetPresetFrame(context.Background(), restds.Preset{
+			Name:       "Pets",
+			URL:        "{baseUrl}/pets",
+			Pagination: restds.Pagination{Type: restds.PaginationTypePage, ParamName: "page", MaxPages: 1},
+		}, restds.Query{})
+		require.Nil(t, err)
+		require.Equal(t, 2, frame.Rows())
+	})
+	t.Run("offset pagination without page size should throw error", func(t *testing.T) {
+		preset := restds.Preset{
+			Name:       "Pets",
+			URL:        "{baseUrl}/pets",
+			Pagination: restds.Pagination{Type: restds.PaginationTypeOffset, ParamName: "offset"},
+		}
+		require.Equal(t, errors.New(`invalid preset "Pets". offset pagination requires a positive page size`), preset.Validate())
+		_, err := ds.GetPresetFrame(context.Background(), preset, restds.Query{})
+		require.Equal(t, errors.New(`invalid preset "Pets". offset pagination requires a positive page size`), err)
+	})
+	t.Run("invalid pagination should throw error", func(t *testing.T) {
+		_, err := ds.GetPresetFrame(context.Background(), restds.Preset{
+			Name:       "Pets",
+			URL:        "{baseUrl}/pets",
+			Pagination: restds.Pagination{Type: restds.PaginationTypeCursor, ParamName: "page"},
+		}, restds.Query{})
+		require.Equal(t, errors.New("invalid/empty pagination cursor selector"), err)
+	})
+}
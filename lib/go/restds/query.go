@@ -18,20 +18,23 @@ const (
 	QueryTypeTSV     QueryType = "tsv"
 	QueryTypeXML     QueryType = "xml"
 	QueryTypeHTML    QueryType = "html"
+	QueryTypePreset  QueryType = "preset"
 )
 
 type Query struct {
-	RefID            string         `json:"refId"`
-	QueryType        QueryType      `json:"type"`
-	URL              string         `json:"url,omitempty"`
-	Method           QueryURLMethod `json:"method,omitempty"`
-	Headers          []KV           `json:"headers,omitempty"`
-	BodyType         BodyType       `json:"bodyType,omitempty"`
-	Body             string         `json:"body,omitempty"`
-	BodyContentType  string         `json:"bodyContentType,omitempty"`
-	BodyForm         []KV           `json:"bodyForm,omitempty"`
-	BodyGraphQLQuery string         `json:"bodyGraphQLQuery,omitempty"`
-	RootSelector     string         `json:"rootSelector,omitempty"`
+	RefID            string            `json:"refId"`
+	QueryType        QueryType         `json:"type"`
+	URL              string            `json:"url,omitempty"`
+	Method           QueryURLMethod    `json:"method,omitempty"`
+	Headers          []KV              `json:"headers,omitempty"`
+	BodyType         BodyType          `json:"bodyType,omitempty"`
+	Body             string            `json:"body,omitempty"`
+	BodyContentType  string            `json:"bodyContentType,omitempty"`
+	BodyForm         []KV              `json:"bodyForm,omitempty"`
+	BodyGraphQLQuery string            `json:"bodyGraphQLQuery,omitempty"`
+	RootSelector     string            `json:"rootSelector,omitempty"`
+	Preset           string            `json:"preset,omitempty"`
+	PresetVariables  map[string]string `json:"presetVariables,omitempty"`
 }
 
 func LoadQuery(backendQuery backend.DataQuery, pluginContext backend.PluginContext) (*Query, error) {
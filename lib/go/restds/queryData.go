@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
 )
 
@@ -37,8 +38,20 @@ func QueryData(ctx context.Context, backendQuery backend.DataQuery, instance dat
 		return *response
 	}
 	switch query.QueryType {
+	case QueryTypePreset:
+		preset, err := findPreset(instance.Presets, query.Preset)
+		if err != nil {
+			response.Error = err
+			return *response
+		}
+		f, err := instance.RestDS.GetPresetFrame(ctx, preset, *query)
+		if err != nil {
+			response.Error = err
+			return *response
+		}
+		response.Frames = append(response.Frames, f)
 	default:
-		body, meta, err := instance.RestDS.GetResponse(*query)
+		body, meta, err := instance.RestDS.GetResponseWithContext(ctx, *query)
 		if err != nil {
 			response.Error = err
 			return *response
@@ -53,6 +66,10 @@ func QueryData(ctx context.Context, backendQuery backend.DataQuery, instance dat
 			response.Error = err
 			return *response
 		}
+		if f.Meta == nil {
+			f.Meta = &data.FrameMeta{}
+		}
+		f.Meta.ExecutedQueryString = instance.RestDS.Config.Redact(meta.RawURL)
 		response.Frames = append(response.Frames, f)
 	}
 	return *response
@@ -0,0 +1,197 @@
+package restds
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type RecordMode string
+
+const (
+	RecordModeNone   RecordMode = "none"
+	RecordModeRecord RecordMode = "record"
+	RecordModeReplay RecordMode = "replay"
+)
+
+const (
+	// EnvKeyRecordMode sets the record mode for the clients without explicit recording config
+	EnvKeyRecordMode = "GF_PLUGIN_RESTDS_RECORD_MODE"
+	// EnvKeyRecordDirectory sets the fixture directory for the clients without explicit recording config
+	EnvKeyRecordDirectory = "GF_PLUGIN_RESTDS_RECORD_DIR"
+)
+
+// Recording defines whether the upstream calls are recorded into or replayed from the fixture directory
+type Recording struct {
+	Mode      RecordMode
+	Directory string
+}
+
+// RecordingFromEnv returns the recording settings from the environment variables
+func RecordingFromEnv() Recording {
+	return Recording{
+		Mode:      RecordMode(strings.TrimSpace(os.Getenv(EnvKeyRecordMode))),
+		Directory: strings.TrimSpace(os.Getenv(EnvKeyRecordDirectory)),
+	}
+}
+
+func (r Recording) enabled() bool {
+	return r.Mode == RecordModeRecord || r.Mode == RecordModeReplay
+}
+
+type fixture struct {
+	Request  fixtureRequest  `json:"request"`
+	Response fixtureResponse `json:"response"`
+}
+
+type fixtureRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type fixtureResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// recorder is a http.RoundTripper which records the request/response pairs into fixture files or replays them.
+// Secrets from the config and their url encoded forms are redacted from the fixtures before hashing the fixture name and writing, and requests are matched by method, redacted url and redacted body.
+type recorder struct {
+	recording Recording
+	secrets   []string
+	headers   []string
+	next      http.RoundTripper
+}
+
+func newRecorder(recording Recording, config *Config, next http.RoundTripper) *recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	rec := &recorder{
+		recording: recording,
+		headers:   []string{HeaderKeyAuthorization, "Cookie", "Set-Cookie", headerKeyIdToken},
+		next:      next,
+	}
+	if config != nil {
+		rec.secrets = config.secrets()
+		if config.AuthenticationMethod == AuthTypeApiKey && config.ApiKeyType != ApiKeyTypeQuery && config.ApiKeyKey != "" {
+			rec.headers = append(rec.headers, config.ApiKeyKey)
+		}
+	}
+	return rec
+}
+
+func (rec *recorder) redact(input string) string {
+	return redactSecrets(input, rec.secrets)
+}
+
+func (rec *recorder) redactHeader(header http.Header) http.Header {
+	out := http.Header{}
+	for k, values := range header {
+		for _, v := range values {
+			out.Add(k, rec.redact(v))
+		}
+	}
+	for _, k := range rec.headers {
+		if out.Get(k) != "" {
+			out.Set(k, redactedValue)
+		}
+	}
+	return out
+}
+
+func (rec *recorder) fixturePath(method string, u string, body string) string {
+	hash := sha256.Sum256([]byte(strings.ToUpper(method) + " " + u + "\n" + body))
+	return filepath.Join(rec.recording.Directory, hex.EncodeToString(hash[:])[:16]+".json")
+}
+
+func (rec *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the request body. %w", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(b))
+		body = string(b)
+	}
+	redactedURL := rec.redact(req.URL.String())
+	redactedBody := rec.redact(body)
+	fixturePath := rec.fixturePath(req.Method, redactedURL, redactedBody)
+	if rec.recording.Mode == RecordModeReplay {
+		return rec.replay(req, fixturePath, redactedURL)
+	}
+	res, err := rec.next.RoundTrip(req)
+	if err != nil || res == nil {
+		return res, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading the response body. %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	f := fixture{
+		Request:  fixtureRequest{Method: req.Method, URL: redactedURL, Header: rec.redactHeader(req.Header), Body: redactedBody},
+		Response: fixtureResponse{StatusCode: res.StatusCode, Header: rec.redactHeader(res.Header), Body: rec.redact(string(resBody))},
+	}
+	if err := writeFixture(fixturePath, f); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (rec *recorder) replay(req *http.Request, fixturePath string, redactedURL string) (*http.Response, error) {
+	b, err := os.ReadFile(fixturePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no recorded response found for %s %s", req.Method, redactedURL)
+		}
+		return nil, fmt.Errorf("error reading the recorded response. %w", err)
+	}
+	f := fixture{}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("error parsing the recorded response. %w", err)
+	}
+	header := f.Response.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Response.StatusCode, http.StatusText(f.Response.StatusCode)),
+		StatusCode:    f.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(f.Response.Body)),
+		ContentLength: int64(len(f.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+func writeFixture(fixturePath string, f fixture) error {
+	if err := os.MkdirAll(filepath.Dir(fixturePath), 0o750); err != nil {
+		return fmt.Errorf("error creating the fixture directory. %w", err)
+	}
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing the fixture. %w", err)
+	}
+	if err := os.WriteFile(fixturePath, b, 0o600); err != nil {
+		return fmt.Errorf("error writing the fixture. %w", err)
+	}
+	return nil
+}
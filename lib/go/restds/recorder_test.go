@@ -0,0 +1,130 @@
+package restds_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/restds"
+)
+
+func TestRestDS_GetResponse_Recording(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer my-secret-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{ "method" : "` + r.Method + `", "token" : "my-secret-token" }`))
+	}))
+	serverURL := server.URL
+	dir := t.TempDir()
+	newDS := func(mode restds.RecordMode) restds.RestDS {
+		config := restds.Config{
+			AuthenticationMethod: restds.AuthTypeBearerToken,
+			BearerToken:          "my-secret-token",
+			Recording:            restds.Recording{Mode: mode, Directory: dir},
+		}
+		return restds.RestDS{Config: config, HTTPClient: restds.NewHTTPClient(&config)}
+	}
+	recordDS := newDS(restds.RecordModeRecord)
+	got, _, err := recordDS.GetResponse(restds.Query{URL: serverURL + "/users"})
+	require.Nil(t, err)
+	require.Equal(t, `{ "method" : "GET", "token" : "my-secret-token" }`, got)
+	got, _, err = recordDS.GetResponse(restds.Query{URL: serverURL + "/users", Method: restds.QueryURLMethodPost, BodyType: restds.BodyTypeRaw, Body: `{"name":"foo"}`})
+	require.Nil(t, err)
+	require.Equal(t, `{ "method" : "POST", "token" : "my-secret-token" }`, got)
+	server.Close()
+	t.Run("fixtures should not contain secrets", func(t *testing.T) {
+		files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		require.Nil(t, err)
+		require.Equal(t, 2, len(files))
+		for _, f := range files {
+			b, err := os.ReadFile(f)
+			require.Nil(t, err)
+			require.False(t, strings.Contains(string(b), "my-secret-token"))
+		}
+	})
+	t.Run("replay should match method, url and body", func(t *testing.T) {
+		replayDS := newDS(restds.RecordModeReplay)
+		got, meta, err := replayDS.GetResponse(restds.Query{URL: serverURL + "/users"})
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, meta.StatusCode)
+		require.Equal(t, "application/json", meta.Headers.Get("Content-Type"))
+		require.Equal(t, `{ "method" : "GET", "token" : "__REDACTED__" }`, got)
+		got, _, err = replayDS.GetResponse(restds.Query{URL: serverURL + "/users", Method: restds.QueryURLMethodPost, BodyType: restds.BodyTypeRaw, Body: `{"name":"foo"}`})
+		require.Nil(t, err)
+		require.Equal(t, `{ "method" : "POST", "token" : "__REDACTED__" }`, got)
+	})
+	t.Run("replay without recording should throw error", func(t *testing.T) {
+		replayDS := newDS(restds.RecordModeReplay)
+		_, _, err := replayDS.GetResponse(restds.Query{URL: serverURL + "/users", Method: restds.QueryURLMethodPost, BodyType: restds.BodyTypeRaw, Body: `{"name":"bar"}`})
+		require.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "no recorded response found for POST "+serverURL+"/users"), err.Error())
+	})
+	t.Run("replay with missing fixture directory should throw error", func(t *testing.T) {
+		config := restds.Config{Recording: restds.Recording{Mode: restds.RecordModeReplay, Directory: filepath.Join(dir, "missing")}}
+		ds := restds.RestDS{Config: config, HTTPClient: restds.NewHTTPClient(&config)}
+		_, _, err := ds.GetResponse(restds.Query{URL: serverURL + "/users"})
+		require.NotNil(t, err)
+		require.False(t, errors.Is(err, os.ErrNotExist))
+	})
+}
+
+func TestRestDS_GetResponse_RecordingEncodedSecrets(t *testing.T) {
+	apiKey := "s3cr3t/with+plus"
+	password := "p@ss w/rd+&=%"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			if r.ParseForm() != nil || r.PostForm.Get("password") != password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: "session", Path: "/"})
+			return
+		}
+		if _, err := r.Cookie("sid"); err != nil || r.URL.Query().Get("key") != apiKey {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"name":"foo"}]`))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	newDS := func(mode restds.RecordMode) restds.RestDS {
+		config := restds.Config{AuthenticationMethod: restds.AuthTypeSessionLogin, Recording: restds.Recording{Mode: mode, Directory: dir}}
+		config.SessionLoginSettings.LoginURL = server.URL + "/login"
+		config.SessionLoginSettings.Username = "admin"
+		config.SessionLoginSettings.Password = password
+		config.SecureValues = map[string]string{"apiKey": apiKey}
+		config.QueryParams = map[string]string{"key": "${__secure.apiKey}"}
+		return restds.RestDS{Config: config, HTTPClient: restds.NewHTTPClient(&config)}
+	}
+	recordDS := newDS(restds.RecordModeRecord)
+	got, _, err := recordDS.GetResponse(restds.Query{URL: server.URL + "/api/users"})
+	require.Nil(t, err)
+	require.Equal(t, `[{"name":"foo"}]`, got)
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.Nil(t, err)
+	require.NotEmpty(t, files)
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		require.Nil(t, err)
+		for _, secret := range []string{apiKey, password} {
+			for _, v := range []string{secret, url.QueryEscape(secret), url.PathEscape(secret)} {
+				require.False(t, strings.Contains(string(b), v), "fixture %s contains %q", f, v)
+			}
+		}
+	}
+	replayDS := newDS(restds.RecordModeReplay)
+	got, _, err = replayDS.GetResponse(restds.Query{URL: server.URL + "/api/users"})
+	require.Nil(t, err)
+	require.Equal(t, `[{"name":"foo"}]`, got)
+}
@@ -0,0 +1,61 @@
+package restds
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const redactedValue = "__REDACTED__"
+
+// secrets returns the secret values of the config which must never be logged or persisted
+func (c *Config) secrets() []string {
+	secrets := []string{}
+	for _, s := range []string{c.BasicAuthPassword, c.ApiKeyValue, c.BearerToken, c.OAuth2Settings.ClientSecret, c.OAuth2Settings.PrivateKey, c.SessionLoginSettings.Password} {
+		if strings.TrimSpace(s) != "" {
+			secrets = append(secrets, s)
+		}
+	}
+	for _, s := range c.SecureValues {
+		if strings.TrimSpace(s) != "" {
+			secrets = append(secrets, s)
+		}
+	}
+	return secrets
+}
+
+// Redact replaces the secret values of the config in the given input
+func (c *Config) Redact(input string) string {
+	return redactSecrets(input, c.secrets())
+}
+
+func (c *Config) redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if msg := c.Redact(err.Error()); msg != err.Error() {
+		return errors.New(msg)
+	}
+	return err
+}
+
+// redactSecrets replaces the secrets along with their url encoded forms, as the secrets are sent in query params and form bodies.
+// Longer values are replaced first so that a value containing another secret is redacted as a whole
+func redactSecrets(input string, secrets []string) string {
+	values := []string{}
+	seen := map[string]bool{}
+	for _, s := range secrets {
+		for _, v := range []string{s, url.QueryEscape(s), url.PathEscape(s)} {
+			if !seen[v] {
+				seen[v] = true
+				values = append(values, v)
+			}
+		}
+	}
+	sort.SliceStable(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, v := range values {
+		input = strings.ReplaceAll(input, v, redactedValue)
+	}
+	return input
+}
@@ -0,0 +1,47 @@
+package restds_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/restds"
+)
+
+func TestConfig_Redact(t *testing.T) {
+	secret := "ab+cd/ef==&x=%41 y"
+	config := restds.Config{ApiKeyValue: secret, BearerToken: "ab"}
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "raw value", input: "token " + secret, want: "token __REDACTED__"},
+		{name: "query escaped value", input: "https://foo.com/bar?k=" + url.QueryEscape(secret) + "&a=b", want: "https://foo.com/bar?k=__REDACTED__&a=b"},
+		{name: "path escaped value", input: "https://foo.com/" + url.PathEscape(secret) + "/bar", want: "https://foo.com/__REDACTED__/bar"},
+		{name: "other secrets", input: "abc", want: "__REDACTED__c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, config.Redact(tt.input))
+		})
+	}
+}
+
+func TestRestDS_GetResponse_ApiKeyQueryRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serverURL := server.URL
+	server.Close()
+	secret := "ab+cd/ef==&x=%41"
+	config := restds.Config{AuthenticationMethod: restds.AuthTypeApiKey, ApiKeyType: restds.ApiKeyTypeQuery, ApiKeyKey: "k", ApiKeyValue: secret}
+	ds := restds.RestDS{Config: config, HTTPClient: restds.NewHTTPClient(&config)}
+	_, _, err := ds.GetResponse(restds.Query{URL: serverURL + "/bar"})
+	require.NotNil(t, err)
+	require.False(t, strings.Contains(err.Error(), url.QueryEscape(secret)), err.Error())
+	require.True(t, strings.Contains(err.Error(), "k=__REDACTED__"), err.Error())
+}
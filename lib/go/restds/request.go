@@ -64,6 +64,9 @@ func GetRequest(config Config, query Query, headersFromGrafana map[string]string
 	req.Header.Add(HeaderKeyContentType, contentTypeHeader)
 	for k, v := range config.Headers {
 		if k != "" {
+			if v, err = config.interpolateSecureValues(v); err != nil {
+				return req, err
+			}
 			req.Header.Add(k, v)
 			if strings.EqualFold(k, HeaderKeyAccept) || strings.EqualFold(k, HeaderKeyContentType) {
 				req.Header.Set(k, v)
@@ -72,9 +75,13 @@ func GetRequest(config Config, query Query, headersFromGrafana map[string]string
 	}
 	for _, header := range query.Headers {
 		if header.Key != "" {
-			req.Header.Add(header.Key, header.Value)
+			v, err := config.interpolateSecureValues(header.Value)
+			if err != nil {
+				return req, err
+			}
+			req.Header.Add(header.Key, v)
 			if strings.EqualFold(header.Key, HeaderKeyAccept) || strings.EqualFold(header.Key, HeaderKeyContentType) {
-				req.Header.Set(header.Key, header.Value)
+				req.Header.Set(header.Key, v)
 			}
 		}
 	}
@@ -96,6 +103,9 @@ func GetRequest(config Config, query Query, headersFromGrafana map[string]string
 	q := req.URL.Query()
 	for k, v := range config.QueryParams {
 		if strings.TrimSpace(k) != "" {
+			if v, err = config.interpolateSecureValues(v); err != nil {
+				return req, err
+			}
 			q.Add(k, v)
 		}
 	}
@@ -1,10 +1,14 @@
 package restds
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 const (
@@ -17,6 +21,7 @@ const (
 type RestDS struct {
 	Config     Config
 	HTTPClient *http.Client
+	PluginID   string
 }
 
 type ResponseMeta struct {
@@ -27,14 +32,47 @@ type ResponseMeta struct {
 }
 
 func (restds *RestDS) GetResponse(query Query) (responseBody string, meta ResponseMeta, err error) {
+	return restds.GetResponseWithContext(context.Background(), query)
+}
+
+// GetResponseWithContext performs the upstream request. Logs, metrics and spans of the upstream call are recorded
+func (restds *RestDS) GetResponseWithContext(ctx context.Context, query Query) (responseBody string, meta ResponseMeta, err error) {
 	req, err := GetRequest(restds.Config, query, map[string]string{})
 	if err != nil {
 		return responseBody, meta, err
 	}
+	req, call := restds.startUpstreamCall(ctx, req)
+	bytesRead := 0
+	defer func() {
+		// errors such as *url.Error carry the full url, which might have the secure values
+		err = restds.Config.redactError(err)
+		call.end(restds.Config.Redact, meta.StatusCode, bytesRead, err)
+	}()
+	if restds.isSessionLogin() && !restds.hasSession(req.URL) {
+		if err := restds.login(req.Context()); err != nil {
+			return responseBody, meta, err
+		}
+	}
 	res, err := restds.HTTPClient.Do(req)
 	if err != nil {
 		return responseBody, meta, err
 	}
+	if res != nil && restds.isSessionLogin() && (res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden) {
+		// session might have expired. login again and retry the request once
+		res.Body.Close()
+		if err := restds.login(req.Context()); err != nil {
+			return responseBody, meta, err
+		}
+		retryReq, err := GetRequest(restds.Config, query, map[string]string{})
+		if err != nil {
+			return responseBody, meta, err
+		}
+		retryReq = retryReq.WithContext(req.Context())
+		otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(retryReq.Header))
+		if res, err = restds.HTTPClient.Do(retryReq); err != nil {
+			return responseBody, meta, err
+		}
+	}
 	if res != nil {
 		defer res.Body.Close()
 		meta.RawURL = req.URL.String()
@@ -42,6 +80,7 @@ func (restds *RestDS) GetResponse(query Query) (responseBody string, meta Respon
 		meta.Status = res.Status
 		meta.StatusCode = res.StatusCode
 		bodyBytes, err := io.ReadAll(res.Body)
+		bytesRead = len(bodyBytes)
 		if err != nil {
 			return "", meta, fmt.Errorf("error reading the url response. %w", err)
 		}
@@ -0,0 +1,30 @@
+package restds
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var secureValueRegex = regexp.MustCompile(`\$\{__secure\.([^}]+)\}`)
+
+// interpolateSecureValues replaces the ${__secure.<key>} placeholders with the values from the secure json data.
+// Errors only refer the key so that the secure values never leak
+func (c *Config) interpolateSecureValues(input string) (string, error) {
+	if !strings.Contains(input, "${__secure.") {
+		return input, nil
+	}
+	var err error
+	output := secureValueRegex.ReplaceAllStringFunc(input, func(match string) string {
+		key := strings.TrimSpace(secureValueRegex.FindStringSubmatch(match)[1])
+		value, ok := c.SecureValues[key]
+		if !ok || value == "" {
+			if err == nil {
+				err = fmt.Errorf("invalid/empty secure value %q", key)
+			}
+			return match
+		}
+		return value
+	})
+	return output, err
+}
@@ -0,0 +1,73 @@
+package restds_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/restds"
+)
+
+func TestGetRequest_SecureValues(t *testing.T) {
+	config := restds.Config{
+		Headers:      map[string]string{"DD-API-KEY": "${__secure.apiKey}", "X-Plain": "plain"},
+		QueryParams:  map[string]string{"application_key": "${__secure.appKey}"},
+		SecureValues: map[string]string{"apiKey": "my-api-key", "appKey": "my-app-key"},
+	}
+	t.Run("placeholders should be resolved from secure values", func(t *testing.T) {
+		req, err := restds.GetRequest(config, restds.Query{URL: "https://foo.com/bar", Headers: []restds.KV{{Key: "X-Combined", Value: "${__secure.apiKey}:${__secure.appKey}"}}}, nil)
+		require.Nil(t, err)
+		require.Equal(t, "my-api-key", req.Header.Get("DD-API-KEY"))
+		require.Equal(t, "plain", req.Header.Get("X-Plain"))
+		require.Equal(t, "my-api-key:my-app-key", req.Header.Get("X-Combined"))
+		require.Equal(t, "my-app-key", req.URL.Query().Get("application_key"))
+	})
+	t.Run("unknown placeholders should throw error without the secure values", func(t *testing.T) {
+		_, err := restds.GetRequest(config, restds.Query{URL: "https://foo.com/bar", Headers: []restds.KV{{Key: "X-Missing", Value: "${__secure.missing}"}}}, nil)
+		require.NotNil(t, err)
+		assert.Equal(t, errors.New(`invalid/empty secure value "missing"`), err)
+	})
+}
+
+func TestRestDS_GetResponse_SecureValuesRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serverURL := server.URL
+	server.Close()
+	config := restds.Config{
+		QueryParams:  map[string]string{"application_key": "${__secure.appKey}"},
+		SecureValues: map[string]string{"appKey": "my-app-key"},
+	}
+	ds := restds.RestDS{Config: config, HTTPClient: restds.NewHTTPClient(&config)}
+	_, _, err := ds.GetResponse(restds.Query{URL: serverURL + "/bar"})
+	require.NotNil(t, err)
+	require.False(t, strings.Contains(err.Error(), "my-app-key"), err.Error())
+	require.True(t, strings.Contains(err.Error(), "application_key=__REDACTED__"), err.Error())
+}
+
+func TestRestDS_GetResponse_SecureQueryParamRedaction(t *testing.T) {
+	secret := "s3cr3t/with+plus&x=%41"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("application_key") != secret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	config := restds.Config{
+		QueryParams:  map[string]string{"application_key": "${__secure.appKey}"},
+		SecureValues: map[string]string{"appKey": secret},
+	}
+	ds := restds.RestDS{Config: config, HTTPClient: restds.NewHTTPClient(&config)}
+	_, meta, err := ds.GetResponse(restds.Query{URL: server.URL + "/bar"})
+	require.Nil(t, err)
+	require.Equal(t, http.StatusOK, meta.StatusCode)
+	executedQueryString := ds.Config.Redact(meta.RawURL)
+	require.Equal(t, server.URL+"/bar?application_key=__REDACTED__", executedQueryString)
+}
@@ -0,0 +1,70 @@
+package restds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	defaultSessionLoginUsernameField = "username"
+	defaultSessionLoginPasswordField = "password"
+)
+
+func (restds *RestDS) isSessionLogin() bool {
+	return restds.Config.AuthenticationMethod == AuthTypeSessionLogin
+}
+
+// hasSession checks whether the cookie jar already holds cookies for the given url
+func (restds *RestDS) hasSession(u *url.URL) bool {
+	if restds.HTTPClient == nil || restds.HTTPClient.Jar == nil || u == nil {
+		return false
+	}
+	return len(restds.HTTPClient.Jar.Cookies(u)) > 0
+}
+
+// login posts the configured credentials to the login url. Cookies set by the login response are stored in the client cookie jar
+func (restds *RestDS) login(ctx context.Context) error {
+	settings := restds.Config.SessionLoginSettings
+	if strings.TrimSpace(settings.LoginURL) == "" {
+		return errors.New("invalid/empty session login url")
+	}
+	if restds.HTTPClient == nil || restds.HTTPClient.Jar == nil {
+		return errors.New("invalid http client. session login requires a cookie jar")
+	}
+	usernameField := settings.UsernameField
+	if usernameField == "" {
+		usernameField = defaultSessionLoginUsernameField
+	}
+	passwordField := settings.PasswordField
+	if passwordField == "" {
+		passwordField = defaultSessionLoginPasswordField
+	}
+	form := url.Values{}
+	for k, v := range settings.FormFields {
+		if strings.TrimSpace(k) != "" {
+			form.Set(k, v)
+		}
+	}
+	form.Set(usernameField, settings.Username)
+	form.Set(passwordField, settings.Password)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, settings.LoginURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("error creating session login request. %w", err)
+	}
+	req.Header.Set(HeaderKeyContentType, "application/x-www-form-urlencoded")
+	res, err := restds.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error performing session login. %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("session login failed. status code: HTTP %d %s", res.StatusCode, http.StatusText(res.StatusCode))
+	}
+	return nil
+}
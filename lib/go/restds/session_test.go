@@ -0,0 +1,113 @@
+package restds_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/restds"
+)
+
+type loginServer struct {
+	mu      sync.Mutex
+	logins  int
+	session string
+}
+
+func (ls *loginServer) expire() {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.session = ""
+}
+
+func (ls *loginServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.ParseForm() != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.PostForm.Get("user") != "admin" || r.PostForm.Get("pass") != "secret" || r.PostForm.Get("tenant") != "main" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		ls.mu.Lock()
+		ls.logins++
+		ls.session = fmt.Sprintf("session-%d", ls.logins)
+		session := ls.session
+		ls.mu.Unlock()
+		http.SetCookie(w, &http.Cookie{Name: "sid", Value: session, Path: "/"})
+		http.Redirect(w, r, "/home", http.StatusFound)
+	})
+	mux.HandleFunc("/home", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html>welcome</html>"))
+	})
+	mux.HandleFunc("/api/users", func(w http.ResponseWriter, r *http.Request) {
+		c, err := r.Cookie("sid")
+		ls.mu.Lock()
+		valid := err == nil && ls.session != "" && c.Value == ls.session
+		ls.mu.Unlock()
+		if !valid {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"name":"foo"}]`))
+	})
+	return mux
+}
+
+func newSessionLoginDS(serverURL string, password string) restds.RestDS {
+	config := restds.Config{AuthenticationMethod: restds.AuthTypeSessionLogin}
+	config.SessionLoginSettings.LoginURL = serverURL + "/login"
+	config.SessionLoginSettings.UsernameField = "user"
+	config.SessionLoginSettings.PasswordField = "pass"
+	config.SessionLoginSettings.Username = "admin"
+	config.SessionLoginSettings.Password = password
+	config.SessionLoginSettings.FormFields = map[string]string{"tenant": "main"}
+	return restds.RestDS{Config: config, HTTPClient: restds.NewHTTPClient(&config)}
+}
+
+func TestRestDS_GetResponse_SessionLogin(t *testing.T) {
+	ls := &loginServer{}
+	server := httptest.NewServer(ls.handler())
+	defer server.Close()
+	t.Run("should login before the first request and reuse the session", func(t *testing.T) {
+		ds := newSessionLoginDS(server.URL, "secret")
+		for i := 0; i < 3; i++ {
+			res, _, err := ds.GetResponse(restds.Query{URL: server.URL + "/api/users"})
+			require.Nil(t, err)
+			require.Equal(t, `[{"name":"foo"}]`, res)
+		}
+		require.Equal(t, 1, ls.logins)
+	})
+	t.Run("should login again when the session expires", func(t *testing.T) {
+		ds := newSessionLoginDS(server.URL, "secret")
+		_, _, err := ds.GetResponse(restds.Query{URL: server.URL + "/api/users"})
+		require.Nil(t, err)
+		ls.expire()
+		res, _, err := ds.GetResponse(restds.Query{URL: server.URL + "/api/users"})
+		require.Nil(t, err)
+		require.Equal(t, `[{"name":"foo"}]`, res)
+		require.Equal(t, 3, ls.logins)
+	})
+	t.Run("invalid credentials should throw error", func(t *testing.T) {
+		ds := newSessionLoginDS(server.URL, "wrong")
+		_, _, err := ds.GetResponse(restds.Query{URL: server.URL + "/api/users"})
+		require.NotNil(t, err)
+		assert.Equal(t, errors.New("session login failed. status code: HTTP 401 Unauthorized"), err)
+	})
+}
+
+func TestNewHTTPClient_SessionLoginJar(t *testing.T) {
+	first := newSessionLoginDS("https://foo.com", "secret")
+	second := newSessionLoginDS("https://foo.com", "secret")
+	require.NotNil(t, first.HTTPClient.Jar)
+	require.NotSame(t, http.DefaultClient, first.HTTPClient)
+	require.NotSame(t, first.HTTPClient.Jar, second.HTTPClient.Jar)
+	require.Nil(t, http.DefaultClient.Jar)
+}
@@ -1,10 +1,6 @@
 package main
 
 import (
-	"encoding/json"
-	"errors"
-	"io"
-	"net/http"
 	"strings"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -22,7 +18,7 @@ func (v *PetStoreRestDriver) LoadConfig(settings backend.DataSourceInstanceSetti
 
 func (v *PetStoreRestDriver) LoadSpec() openapi3.Spec {
 	specUrl := "https://petstore3.swagger.io/api/v3/openapi.json"
-	spec, err := loadSpecFromUrl(specUrl)
+	spec, err := restds.SpecFromURL(specUrl)
 	if err != nil {
 		return openapi3.Spec{Openapi: "3.0.3"}
 	}
@@ -35,29 +31,3 @@ func (v *PetStoreRestDriver) LoadSpec() openapi3.Spec {
 	// endregion
 	return spec
 }
-
-func loadSpecFromUrl(specUrl string) (openapi3.Spec, error) {
-	spec := &openapi3.Spec{Openapi: "3.0.3"}
-	req, _ := http.NewRequest(http.MethodGet, specUrl, nil)
-	hc := http.DefaultClient
-	res, err := hc.Do(req)
-	if err != nil {
-		return *spec, err
-	}
-	if res != nil {
-		defer res.Body.Close()
-		bodyBytes, err := io.ReadAll(res.Body)
-		if err != nil {
-			return *spec, err
-		}
-		if res.StatusCode >= http.StatusBadRequest {
-			return *spec, errors.New("invalid status code." + res.Status)
-		}
-		err = json.Unmarshal(bodyBytes, &spec)
-		if err != nil {
-			return *spec, err
-		}
-		return *spec, nil
-	}
-	return *spec, errors.New("invalid/empty status")
-}
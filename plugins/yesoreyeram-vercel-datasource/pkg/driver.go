@@ -4,8 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/swaggest/openapi-go/openapi3"
@@ -42,7 +40,7 @@ func (v *VercelRestDriver) LoadConfig(settings backend.DataSourceInstanceSetting
 
 func (v *VercelRestDriver) LoadSpec() openapi3.Spec {
 	spec := openapi3.Spec{Openapi: "3.0.3"}
-	if spec, err := loadSpecFromUrl("https://openapi.vercel.sh"); err == nil {
+	if spec, err := restds.SpecFromURL("https://openapi.vercel.sh"); err == nil {
 		// This part seems to be failing
 		// https://github.com/vercel/community/discussions/646#discussioncomment-4553140
 		return spec
@@ -128,30 +126,4 @@ func (v *VercelRestDriver) LoadSpec() openapi3.Spec {
 	return spec
 }
 
-func loadSpecFromUrl(u string) (openapi3.Spec, error) {
-	spec := &openapi3.Spec{Openapi: "3.0.3"}
-	req, _ := http.NewRequest(http.MethodGet, "https://openapi.vercel.sh", nil)
-	hc := http.DefaultClient
-	res, err := hc.Do(req)
-	if err != nil {
-		return *spec, err
-	}
-	if res != nil {
-		defer res.Body.Close()
-		bodyBytes, err := io.ReadAll(res.Body)
-		if err != nil {
-			return *spec, err
-		}
-		if res.StatusCode >= http.StatusBadRequest {
-			return *spec, errors.New("invalid status code." + res.Status)
-		}
-		err = json.Unmarshal(bodyBytes, &spec)
-		if err != nil {
-			return *spec, err
-		}
-		return *spec, nil
-	}
-	return *spec, errors.New("invalid/empty status")
-}
-
 func pointer[T any](input T) *T { return &input }
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/yesoreyeram/grafana-plugins/lib/go/anyframer"
+	"github.com/yesoreyeram/grafana-plugins/lib/go/restds"
+)
+
+func (v *VercelRestDriver) LoadPresets() []restds.Preset {
+	return []restds.Preset{
+		{
+			Name:         "Deployments",
+			Description:  "List deployments under the authenticated user or team",
+			URL:          "{baseUrl}/v6/deployments",
+			RootSelector: "deployments",
+			Columns: []anyframer.Column{
+				{Selector: "uid", Alias: "id", Format: anyframer.ColumnFormatString},
+				{Selector: "name", Format: anyframer.ColumnFormatString},
+				{Selector: "url", Format: anyframer.ColumnFormatString},
+				{Selector: "state", Format: anyframer.ColumnFormatString},
+				{Selector: "target", Format: anyframer.ColumnFormatString},
+				{Selector: "creator.username", Alias: "creator", Format: anyframer.ColumnFormatString},
+				{Selector: "created", Format: anyframer.ColumnFormatUnixMsecTimeStamp},
+			},
+			Pagination: restds.Pagination{
+				Type:           restds.PaginationTypeCursor,
+				ParamName:      "until",
+				SizeParamName:  "limit",
+				Size:           100,
+				CursorSelector: "pagination.next",
+			},
+			FieldLinks: []restds.FieldLink{
+				{Field: "url", Title: "Open deployment", URL: "https://${__data.fields.url}", TargetBlank: true},
+			},
+		},
+		{
+			Name:         "Projects",
+			Description:  "List projects of the authenticated user or team",
+			URL:          "{baseUrl}/v9/projects",
+			RootSelector: "projects",
+			Columns: []anyframer.Column{
+				{Selector: "id", Format: anyframer.ColumnFormatString},
+				{Selector: "name", Format: anyframer.ColumnFormatString},
+				{Selector: "framework", Format: anyframer.ColumnFormatString},
+				{Selector: "nodeVersion", Format: anyframer.ColumnFormatString},
+				{Selector: "createdAt", Format: anyframer.ColumnFormatUnixMsecTimeStamp},
+				{Selector: "updatedAt", Format: anyframer.ColumnFormatUnixMsecTimeStamp},
+			},
+			Pagination: restds.Pagination{
+				Type:           restds.PaginationTypeCursor,
+				ParamName:      "until",
+				SizeParamName:  "limit",
+				Size:           100,
+				CursorSelector: "pagination.next",
+			},
+		},
+	}
+}
@@ -1,7 +1,7 @@
 import { DataSourceInstanceSettings, MetricFindValue } from '@grafana/data';
 import { DataSourceWithBackend } from '@grafana/runtime';
 import type { OpenAPI3Spec } from './types/openapi';
-import type { VercelQuery, VercelConfig, VercelVariableQuery, GetResourceCall, GetResourceCallPing, GetResourceCallOpenAPISpec3 } from './types';
+import type { VercelQuery, VercelConfig, VercelVariableQuery, GetResourceCall, GetResourceCallPing, GetResourceCallOpenAPISpec3, GetResourceCallPresets, Preset } from './types';
 
 export class VercelDS extends DataSourceWithBackend<VercelQuery, VercelConfig> {
   constructor(instanceSettings: DataSourceInstanceSettings<VercelConfig>) {
@@ -23,4 +23,7 @@ export class VercelDS extends DataSourceWithBackend<VercelQuery, VercelConfig> {
   getOpenAPI3Spec = (): Promise<OpenAPI3Spec> => {
     return this.getResource<GetResourceCallOpenAPISpec3>('openapi3');
   };
+  getPresets = (): Promise<Preset[]> => {
+    return this.getResource<GetResourceCallPresets>('presets');
+  };
 }
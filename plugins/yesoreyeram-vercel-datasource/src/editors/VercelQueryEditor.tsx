@@ -3,7 +3,7 @@ import { InlineFormLabel, Input, Select } from '@grafana/ui';
 import type { QueryEditorProps } from '@grafana/data/types';
 import type { VercelDS } from './../datasource';
 import type { OpenAPI3Spec } from './../types/openapi';
-import type { VercelQuery, VercelConfig, VercelQueryOpenApi3 } from './../types';
+import type { VercelQuery, VercelConfig, VercelQueryOpenApi3, VercelQueryPreset, Preset } from './../types';
 
 const buildQuery = (baseSpec: OpenAPI3Spec, newQuery: VercelQueryOpenApi3): VercelQuery => {
   const currentSpec = newQuery?.builder_options || {};
@@ -36,8 +36,24 @@ export const VercelQueryEditor = (props: VercelQueryEditorProps) => {
   if (query.queryType === 'raw') {
     return <></>;
   }
+  if (query.queryType === 'preset') {
+    return (
+      <>
+        <QueryTypeEditor query={query} onChange={onChange} />
+        <PresetEditor
+          datasource={datasource}
+          query={query}
+          onChange={(newQuery) => {
+            onChange(newQuery);
+            onRunQuery();
+          }}
+        />
+      </>
+    );
+  }
   return (
     <>
+      <QueryTypeEditor query={query} onChange={onChange} />
       {spec === null ? (
         <>Loading Query Editor</>
       ) : (
@@ -56,6 +72,46 @@ export const VercelQueryEditor = (props: VercelQueryEditorProps) => {
   );
 };
 
+const QueryTypeEditor = (props: { query: VercelQuery; onChange: (value: VercelQuery) => void }) => {
+  const { query, onChange } = props;
+  const queryTypeOptions = [
+    { value: 'openApi3', label: 'OpenAPI' },
+    { value: 'preset', label: 'Preset' },
+  ];
+  return (
+    <div style={{ display: 'flex', justifyContent: 'space-between', gap: '5px', marginBottom: '5px' }}>
+      <InlineFormLabel width={10}>Query Type</InlineFormLabel>
+      <Select
+        options={queryTypeOptions}
+        value={query.queryType || 'openApi3'}
+        onChange={(e) => {
+          if (e.value === 'preset') {
+            onChange({ refId: query.refId, queryType: 'preset', type: 'preset' });
+            return;
+          }
+          onChange({ refId: query.refId, queryType: 'openApi3', url: '', method: 'GET', builder_options: {} });
+        }}
+      />
+    </div>
+  );
+};
+
+const PresetEditor = (props: { datasource: VercelDS; query: VercelQueryPreset; onChange: (value: VercelQuery) => void }) => {
+  const { datasource, query, onChange } = props;
+  const { getPresets } = datasource;
+  const [presets, setPresets] = useState<Preset[]>([]);
+  useEffect(() => {
+    getPresets().then(setPresets).catch(console.error);
+  }, [getPresets]);
+  const presetOptions = presets.map((p) => ({ value: p.name, label: p.name, description: p.description }));
+  return (
+    <div style={{ display: 'flex', justifyContent: 'space-between', gap: '5px' }}>
+      <InlineFormLabel width={10}>Preset</InlineFormLabel>
+      <Select options={presetOptions} value={query.preset} onChange={(e) => onChange({ ...query, type: 'preset', preset: e.value })} />
+    </div>
+  );
+};
+
 const OpenSpec3Editor = (props: { spec: OpenAPI3Spec; query: VercelQueryOpenApi3; onChange: (value: VercelQuery) => void }) => {
   const { spec, query, onChange } = props;
   return (
@@ -8,7 +8,7 @@ export type VercelSecureConfig = {
   apiToken: string;
 };
 export type KV = { key: string; value: string };
-export type VercelQueryType = 'openApi3' | 'raw';
+export type VercelQueryType = 'openApi3' | 'preset' | 'raw';
 export type VercelQueryBase<T extends VercelQueryType> = { queryType: T } & DataQuery;
 export type OpenAPI3Query = {
   type?: 'json' | 'csv' | 'xml' | 'tsv' | 'auto';
@@ -32,10 +32,22 @@ export type VercelQueryOpenApi3 = {
   rootSelector?: string;
 } & OpenAPI3Query &
   VercelQueryBase<'openApi3'>;
+export type VercelQueryPreset = {
+  type: 'preset';
+  preset?: string;
+  presetVariables?: Record<string, string>;
+} & VercelQueryBase<'preset'>;
 export type VercelQueryRaw = {} & VercelQueryBase<'raw'>;
-export type VercelQuery = VercelQueryOpenApi3 | VercelQueryRaw;
+export type VercelQuery = VercelQueryOpenApi3 | VercelQueryPreset | VercelQueryRaw;
 export type VercelVariableQuery = {};
 
+export type Preset = {
+  name: string;
+  description?: string;
+  url: string;
+  variables?: Record<string, string>;
+};
+
 export type GetResourceCallBase<P extends string, Q extends Record<string, any>, R extends unknown> = {
   path: P;
   query?: Q;
@@ -43,4 +55,5 @@ export type GetResourceCallBase<P extends string, Q extends Record<string, any>,
 };
 export type GetResourceCallPing = GetResourceCallBase<'ping', {}, 'pong'>;
 export type GetResourceCallOpenAPISpec3 = GetResourceCallBase<'openapi3', {}, OpenAPI3Spec>;
-export type GetResourceCall = GetResourceCallPing | GetResourceCallOpenAPISpec3;
+export type GetResourceCallPresets = GetResourceCallBase<'presets', {}, Preset[]>;
+export type GetResourceCall = GetResourceCallPing | GetResourceCallOpenAPISpec3 | GetResourceCallPresets;